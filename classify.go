@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// The canonical Kinds of the errors classified by Classify and KindOf.
+const (
+	KindNotFound         Kind = "not_found"
+	KindPermissionDenied Kind = "permission_denied"
+	KindDeadlineExceeded Kind = "deadline_exceeded"
+	KindCanceled         Kind = "canceled"
+	KindUnavailable      Kind = "unavailable"
+)
+
+// The remaining canonical Kinds. See the errkind pkg for their meaning, and
+// for the default HTTP status and gRPC code of each.
+const (
+	KindInvalidArgument    Kind = "invalid_argument"
+	KindAlreadyExists      Kind = "already_exists"
+	KindUnauthenticated    Kind = "unauthenticated"
+	KindResourceExhausted  Kind = "resource_exhausted"
+	KindFailedPrecondition Kind = "failed_precondition"
+	KindAborted            Kind = "aborted"
+	KindInternal           Kind = "internal"
+	KindUnimplemented      Kind = "unimplemented"
+)
+
+// ClassifyOpt marks the wrapped error to be classified into one of the
+// canonical Kinds. See Classify for more info.
+type ClassifyOpt bool
+
+// Classify classifies the wrapped error into one of the canonical Kinds when
+// provided to Wrap. This allows the I/O errors of the std lib, (i.e. fs.ErrNotExist
+// or a syscall.Errno), to be matched by Kind the same as the errors of this pkg:
+//
+//	f, err := os.Open(name)
+//	if err != nil {
+//		return errors.Wrap(err, errors.Classify)
+//	}
+//
+//	// elsewhere
+//	if errors.Is(err, errors.KindNotFound) {
+//		// handle not found
+//	}
+//
+// A Kind provided to Wrap, or found in the wrapped error, takes precedence
+// over the classification.
+const Classify ClassifyOpt = true
+
+// KindOf returns the Kind of the error. The first Kind found in the error
+// tree is returned. When the error tree has no Kind, the Kind of the first
+// registered Rule matching the error is returned, see RegisterRules. Otherwise,
+// the error is classified into one of the canonical Kinds, the same as with
+// Classify. An empty Kind is returned when the error can't be classified.
+func KindOf(err error) Kind {
+	if kind := getKind(err); kind != "" {
+		return kind
+	}
+	if r, ok := matchRule(err); ok && r.Kind != "" {
+		return r.Kind
+	}
+	return classifyKind(err)
+}
+
+func classifyKind(err error) Kind {
+	if err == nil {
+		return ""
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return KindCanceled
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return KindDeadlineExceeded
+	case errors.Is(err, fs.ErrNotExist):
+		return KindNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return KindPermissionDenied
+	}
+	if kind := classifyErrno(err); kind != "" {
+		return kind
+	}
+
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return KindDeadlineExceeded
+	}
+	return ""
+}
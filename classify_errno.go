@@ -0,0 +1,28 @@
+//go:build !plan9
+
+package errors
+
+import (
+	"errors"
+	"syscall"
+)
+
+func classifyErrno(err error) Kind {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return ""
+	}
+
+	switch errno {
+	case syscall.ENOENT:
+		return KindNotFound
+	case syscall.EACCES, syscall.EPERM:
+		return KindPermissionDenied
+	case syscall.ETIMEDOUT:
+		return KindDeadlineExceeded
+	case syscall.ECONNREFUSED, syscall.ECONNRESET, syscall.ECONNABORTED,
+		syscall.EHOSTUNREACH, syscall.ENETUNREACH, syscall.ENETDOWN:
+		return KindUnavailable
+	}
+	return ""
+}
@@ -0,0 +1,6 @@
+package errors
+
+// classifyErrno is a noop, as plan9 has no syscall.Errno.
+func classifyErrno(err error) Kind {
+	return ""
+}
@@ -0,0 +1,24 @@
+//go:build !plan9
+
+package errors_test
+
+import (
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestClassify_Errno(t *testing.T) {
+	runClassifyTests(t, []classifyTest{
+		{name: "syscall.EACCES", input: syscall.EACCES, want: errors.KindPermissionDenied},
+		{name: "syscall.ETIMEDOUT", input: syscall.ETIMEDOUT, want: errors.KindDeadlineExceeded},
+		{
+			name:  "syscall.ECONNREFUSED",
+			input: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+			want:  errors.KindUnavailable,
+		},
+	})
+}
@@ -0,0 +1,64 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestClassify(t *testing.T) {
+	_, notExistErr := os.Open(filepath.Join(t.TempDir(), "missing"))
+
+	tests := []classifyTest{
+		{name: "fs.ErrNotExist", input: notExistErr, want: errors.KindNotFound},
+		{name: "fs.ErrPermission", input: fmt.Errorf("wrapped: %w", fs.ErrPermission), want: errors.KindPermissionDenied},
+		{name: "os.ErrDeadlineExceeded", input: os.ErrDeadlineExceeded, want: errors.KindDeadlineExceeded},
+		{name: "context.Canceled", input: context.Canceled, want: errors.KindCanceled},
+		{name: "context.DeadlineExceeded", input: context.DeadlineExceeded, want: errors.KindDeadlineExceeded},
+		{name: "unclassified", input: fmt.Errorf("unknown"), want: ""},
+	}
+
+	runClassifyTests(t, tests)
+
+	t.Run("without Classify the error is not classified", func(t *testing.T) {
+		err := errors.Wrap(context.Canceled)
+		eq(t, false, errors.Is(err, errors.KindCanceled))
+	})
+
+	t.Run("provided kinds take precedence", func(t *testing.T) {
+		err := errors.Wrap(context.Canceled, errors.Classify, errors.Kind("provided"))
+		eq(t, errors.Kind("provided"), errors.KindOf(err))
+
+		err = errors.Wrap(errors.Wrap(context.Canceled, errors.Kind("inner")), errors.Classify)
+		eq(t, errors.Kind("inner"), errors.KindOf(err))
+		eq(t, false, errors.Is(err, errors.KindCanceled))
+	})
+}
+
+type classifyTest struct {
+	name  string
+	input error
+	want  errors.Kind
+}
+
+func runClassifyTests(t *testing.T, tests []classifyTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eq(t, tt.want, errors.KindOf(tt.input))
+
+			err := errors.Wrap(tt.input, errors.Classify)
+			eq(t, tt.want, errors.KindOf(err))
+			if tt.want != "" {
+				eq(t, true, errors.Is(err, tt.want))
+				eq(t, true, errors.Is(tt.want, err))
+			}
+		})
+	}
+}
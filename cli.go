@@ -0,0 +1,192 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var exitCodes = struct {
+	mu    sync.RWMutex
+	codes map[Kind]int
+}{
+	// the defaults follow the conventions of sysexits.h, for the canonical kinds
+	codes: map[Kind]int{
+		KindInvalidArgument:    64, // EX_USAGE
+		KindNotFound:           66, // EX_NOINPUT
+		KindAlreadyExists:      73, // EX_CANTCREAT
+		KindPermissionDenied:   77, // EX_NOPERM
+		KindUnauthenticated:    77, // EX_NOPERM
+		KindResourceExhausted:  75, // EX_TEMPFAIL
+		KindFailedPrecondition: 65, // EX_DATAERR
+		KindAborted:            75, // EX_TEMPFAIL
+		KindUnavailable:        69, // EX_UNAVAILABLE
+		KindDeadlineExceeded:   75, // EX_TEMPFAIL
+		KindCanceled:           130,
+		KindInternal:           70, // EX_SOFTWARE
+		KindUnimplemented:      69, // EX_UNAVAILABLE
+	},
+}
+
+// SetExitCode sets the exit code ExitCode returns for errors of the kind.
+// This allows for the defaults to be overridden, and for custom kinds to
+// have an exit code of their own. The returned func restores the previous
+// exit code of the kind, which is useful in tests.
+func SetExitCode(kind Kind, code int) (restore func()) {
+	exitCodes.mu.Lock()
+	defer exitCodes.mu.Unlock()
+
+	prev, existed := exitCodes.codes[kind]
+	exitCodes.codes[kind] = code
+
+	return func() {
+		exitCodes.mu.Lock()
+		defer exitCodes.mu.Unlock()
+		if existed {
+			exitCodes.codes[kind] = prev
+		} else {
+			delete(exitCodes.codes, kind)
+		}
+	}
+}
+
+// ExitCode returns the exit code for the error, for use with os.Exit. The
+// first Kind found in the error tree with an exit code determines the exit
+// code. The defaults follow the conventions of sysexits.h, see SetExitCode
+// for providing your own. When the error has no such Kind, the error is
+// classified with KindOf. Errors that can't be mapped have an exit code
+// of 1, while a nil error has an exit code of 0.
+//
+//	if err := run(); err != nil {
+//		errors.Fprint(os.Stderr, err, errors.PrintOpts{})
+//		os.Exit(errors.ExitCode(err))
+//	}
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	// the kinds are resolved without holding the lock, as a Kinder or a
+	// rule's matcher may call SetExitCode, see matchRule
+	for _, kind := range Kinds(err) {
+		if code, ok := exitCode(kind); ok {
+			return code
+		}
+	}
+	if code, ok := exitCode(KindOf(err)); ok {
+		return code
+	}
+	return 1
+}
+
+func exitCode(kind Kind) (int, bool) {
+	exitCodes.mu.RLock()
+	defer exitCodes.mu.RUnlock()
+	code, ok := exitCodes.codes[kind]
+	return code, ok
+}
+
+// PrintOpts are the options for Fprint.
+type PrintOpts struct {
+	// Prefix is written before the message. Defaults to "Error: ".
+	Prefix string
+
+	// Verbose prints the error tree after the message, including the
+	// Kind, KVs and Frame of each error. See RenderTree for more info.
+	Verbose bool
+
+	// VerboseEnv is the name of an environment variable that enables
+	// Verbose, when set to a true value, (i.e. DEBUG=1).
+	VerboseEnv string
+}
+
+// Fprint writes a concise, human friendly message of the error to w. Unlike
+// the Error method, no stack frames are included and joined errors are
+// written as an indented list, followed by the hints of the error. This is
+// useful for the errors of a CLI:
+//
+//	Error: deploy failed: 2 errors occurred:
+//	  - service "api": image not found
+//	  - service "web": permission denied
+//	Hint: run `foo login` first
+//	Help: https://example.com/docs/deploy
+//
+// When Verbose is set, the error tree is written as well, to aid debugging.
+func Fprint(w io.Writer, err error, opts PrintOpts) error {
+	if err == nil {
+		return nil
+	}
+
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = "Error: "
+	}
+
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	writeConcise(&sb, Inspect(err), "")
+	for _, h := range Hints(err) {
+		if h.Hint != "" {
+			sb.WriteString("Hint: " + h.Hint + "\n")
+		}
+		if h.HelpURL != "" {
+			sb.WriteString("Help: " + h.HelpURL + "\n")
+		}
+	}
+	if _, werr := io.WriteString(w, sb.String()); werr != nil {
+		return werr
+	}
+
+	if !opts.Verbose && !envEnabled(opts.VerboseEnv) {
+		return nil
+	}
+	if _, werr := io.WriteString(w, "\n"); werr != nil {
+		return werr
+	}
+	return RenderTree(w, err)
+}
+
+// writeConcise writes the messages of the layers, with the errors of a
+// join written as an indented list.
+func writeConcise(sb *strings.Builder, layers []Layer, indent string) {
+	var msgs []string
+	for _, layer := range layers {
+		if layer.Msg != "" {
+			msgs = append(msgs, layer.Msg)
+		}
+	}
+
+	var kids [][]Layer
+	for _, child := range layers[len(layers)-1].Children {
+		// a joined error that is one of its own ancestors has no layers,
+		// see Inspect
+		if len(child) > 0 {
+			kids = append(kids, child)
+		}
+	}
+	if len(kids) == 0 {
+		sb.WriteString(strings.Join(msgs, ": ") + "\n")
+		return
+	}
+
+	msgs = append(msgs, fmt.Sprintf("%d errors occurred", len(kids)))
+	if len(kids) == 1 {
+		msgs[len(msgs)-1] = "1 error occurred"
+	}
+	sb.WriteString(strings.Join(msgs, ": ") + ":\n")
+	for _, child := range kids {
+		sb.WriteString(indent + "  - ")
+		writeConcise(sb, child, indent+"    ")
+	}
+}
+
+func envEnabled(name string) bool {
+	if name == "" {
+		return false
+	}
+	enabled, _ := strconv.ParseBool(os.Getenv(name))
+	return enabled
+}
@@ -0,0 +1,145 @@
+package errors_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestExitCode(t *testing.T) {
+	t.Cleanup(errors.SetExitCode("cli_custom", 42))
+
+	tests := []struct {
+		name  string
+		input error
+		want  int
+	}{
+		{name: "nil error", input: nil, want: 0},
+		{name: "canonical kind", input: errors.New("missing", errors.KindNotFound), want: 66},
+		{name: "errkind kind", input: errors.New("bad flag", errors.Kind("invalid_argument")), want: 64},
+		{name: "custom kind", input: errors.New("custom", errors.Kind("cli_custom")), want: 42},
+		{
+			name:  "first kind with an exit code",
+			input: errors.Wrap(errors.New("denied", errors.KindPermissionDenied), errors.Kind("unmapped")),
+			want:  77,
+		},
+		{name: "classified error", input: fmt.Errorf("ctx: %w", context.Canceled), want: 130},
+		{name: "unmapped error", input: fmt.Errorf("unmapped"), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eq(t, tt.want, errors.ExitCode(tt.input))
+		})
+	}
+}
+
+func TestFprint(t *testing.T) {
+	t.Run("nil error writes nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.Fprint(&buf, nil, errors.PrintOpts{})))
+		eq(t, "", buf.String())
+	})
+
+	t.Run("concise message without frames", func(t *testing.T) {
+		err := errors.Wrap(
+			fmt.Errorf("read config: %w", errors.New("file not found", errors.KindNotFound)),
+			"start server",
+		)
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.Fprint(&buf, err, errors.PrintOpts{})))
+		eq(t, "Error: start server: read config: file not found\n", buf.String())
+	})
+
+	t.Run("joined errors are listed", func(t *testing.T) {
+		err := errors.Wrap(errors.Join(
+			errors.New("image not found"),
+			errors.Join(errors.New("nested 1"), fmt.Errorf("nested 2")),
+			"deploy failed",
+		))
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.Fprint(&buf, err, errors.PrintOpts{Prefix: "deploy: "})))
+
+		want := `deploy: deploy failed: 2 errors occurred:
+  - image not found
+  - 2 errors occurred:
+      - nested 1
+      - nested 2
+`
+		eq(t, want, buf.String())
+	})
+
+	t.Run("verbose writes the error tree", func(t *testing.T) {
+		err := errors.New("boom", errors.Kind("internal"), errors.NoFrame)
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.Fprint(&buf, err, errors.PrintOpts{Verbose: true})))
+		eq(t, "Error: boom\n\nboom kind=internal\n", buf.String())
+	})
+
+	t.Run("verbose enabled by env var", func(t *testing.T) {
+		t.Setenv("ERRORS_TEST_DEBUG", "true")
+		err := errors.New("boom", errors.NoFrame)
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.Fprint(&buf, err, errors.PrintOpts{VerboseEnv: "ERRORS_TEST_DEBUG"})))
+		eq(t, 2, strings.Count(buf.String(), "boom"))
+
+		t.Setenv("ERRORS_TEST_DEBUG", "0")
+		buf.Reset()
+		must(t, eq(t, nil, errors.Fprint(&buf, err, errors.PrintOpts{VerboseEnv: "ERRORS_TEST_DEBUG"})))
+		eq(t, "Error: boom\n", buf.String())
+	})
+
+	t.Run("cyclic error tree", func(t *testing.T) {
+		cyclic := &cyclicErr{msg: "cycle"}
+		cyclic.next = errors.Join(cyclic, errors.New("other", errors.NoFrame), errors.NoFrame)
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.Fprint(&buf, cyclic, errors.PrintOpts{})))
+		eq(t, "Error: cycle: 1 error occurred:\n  - other\n", buf.String())
+	})
+}
+
+func TestSetExitCode(t *testing.T) {
+	t.Run("restore reverts to the previous exit code", func(t *testing.T) {
+		err := errors.New("missing", errors.KindNotFound)
+
+		restore := errors.SetExitCode(errors.KindNotFound, 2)
+		eq(t, 2, errors.ExitCode(err))
+		restore()
+		eq(t, 66, errors.ExitCode(err))
+	})
+
+	t.Run("restore removes a new exit code", func(t *testing.T) {
+		err := errors.New("custom", errors.Kind("cli_restored"))
+
+		restore := errors.SetExitCode("cli_restored", 3)
+		eq(t, 3, errors.ExitCode(err))
+		restore()
+		eq(t, 1, errors.ExitCode(err))
+	})
+
+	t.Run("kinds are resolved without holding the lock", func(t *testing.T) {
+		t.Cleanup(errors.SetExitCode("cli_kinder", 4))
+
+		err := errors.Wrap(exitCodeKinder{}, errors.NoFrame)
+		eq(t, 4, errors.ExitCode(err))
+	})
+}
+
+// exitCodeKinder sets the exit code of its kind when asked for it.
+type exitCodeKinder struct{}
+
+func (exitCodeKinder) Error() string { return "exit code kinder" }
+
+func (exitCodeKinder) Kind() errors.Kind {
+	errors.SetExitCode("cli_kinder", 4)
+	return "cli_kinder"
+}
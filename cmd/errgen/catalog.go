@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jsteenb2/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog is the declarative description of a package's errors. A catalog
+// is made up of the Kinds a package exposes, and the errors that are
+// constructed from those kinds.
+type Catalog struct {
+	// Package is the name of the go package the generated code belongs to.
+	Package string `json:"package" yaml:"package"`
+	// Import is the import path of the errors module. Defaults to
+	// github.com/jsteenb2/errors.
+	Import string `json:"import,omitempty" yaml:"import,omitempty"`
+
+	Kinds  []KindDef  `json:"kinds" yaml:"kinds"`
+	Errors []ErrorDef `json:"errors" yaml:"errors"`
+}
+
+// KindDef describes a single errors.Kind.
+type KindDef struct {
+	// Name is the go identifier suffix, the constant is named Kind<Name>.
+	Name string `json:"name" yaml:"name"`
+	// Value is the string value of the Kind.
+	Value string `json:"value" yaml:"value"`
+	Doc   string `json:"doc,omitempty" yaml:"doc,omitempty"`
+	// Status maps a transport (i.e. http, grpc, exit) to the status
+	// that an error of this kind should be reported as.
+	Status map[string]int `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// ErrorDef describes a single error constructor.
+type ErrorDef struct {
+	// Name is the go identifier suffix, the constructor is named New<Name>.
+	Name string `json:"name" yaml:"name"`
+	// Kind references the Name of a KindDef in the catalog.
+	Kind    string `json:"kind" yaml:"kind"`
+	Code    string `json:"code,omitempty" yaml:"code,omitempty"`
+	Message string `json:"message" yaml:"message"`
+	Doc     string `json:"doc,omitempty" yaml:"doc,omitempty"`
+	// KVs are the required KVs, each is a param of the constructor.
+	KVs []KVDef `json:"kvs,omitempty" yaml:"kvs,omitempty"`
+}
+
+// KVDef describes a required KV of an error.
+type KVDef struct {
+	Key string `json:"key" yaml:"key"`
+	// Type is the go type of the constructor param. Defaults to any.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	Doc  string `json:"doc,omitempty" yaml:"doc,omitempty"`
+}
+
+// loadCatalog decodes and validates the catalog at the path. A non-empty pkg
+// overrides the package name of the catalog, and is validated along with it.
+func loadCatalog(path, pkg string) (Catalog, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Catalog{}, errors.Wrap(err)
+	}
+
+	var c Catalog
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&c)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		err = dec.Decode(&c)
+	default:
+		return Catalog{}, errors.New("unsupported catalog extension, expected one of .json, .yaml or .yml", errors.KVs("ext", ext))
+	}
+	if err != nil {
+		return Catalog{}, errors.Wrap(err, "failed to decode catalog", errors.KVs("path", path))
+	}
+	if c.Import == "" {
+		c.Import = "github.com/jsteenb2/errors"
+	}
+	if pkg != "" {
+		c.Package = pkg
+	}
+
+	return c, c.validate()
+}
+
+func (c Catalog) validate() error {
+	var errs []error
+	addErr := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if !token.IsIdentifier(c.Package) {
+		addErr("package %q is not a valid go package name", c.Package)
+	}
+
+	kinds := make(map[string]bool, len(c.Kinds))
+	kindVals := make(map[string]string, len(c.Kinds))
+	for i, k := range c.Kinds {
+		switch {
+		case !isExportable(k.Name):
+			addErr("kinds[%d]: name %q must be a valid exported go identifier", i, k.Name)
+		case kinds[k.Name]:
+			addErr("kinds[%d]: duplicate kind name %q", i, k.Name)
+		}
+		kinds[k.Name] = true
+
+		switch prev, ok := kindVals[k.Value]; {
+		case k.Value == "":
+			addErr("kinds[%d]: %s is missing a value", i, k.Name)
+		case ok:
+			addErr("kinds[%d]: %s has the same value %q as %s", i, k.Name, k.Value, prev)
+		}
+		kindVals[k.Value] = k.Name
+
+		for transport := range k.Status {
+			if !token.IsIdentifier(goName(transport)) {
+				addErr("kinds[%d]: status transport %q is not a valid identifier", i, transport)
+			}
+		}
+	}
+
+	errNames := make(map[string]bool, len(c.Errors))
+	codes := make(map[string]string, len(c.Errors))
+	for i, e := range c.Errors {
+		switch {
+		case !isExportable(e.Name):
+			addErr("errors[%d]: name %q must be a valid exported go identifier", i, e.Name)
+		case errNames[e.Name]:
+			addErr("errors[%d]: duplicate error name %q", i, e.Name)
+		}
+		errNames[e.Name] = true
+
+		if !kinds[e.Kind] {
+			addErr("errors[%d]: %s references undefined kind %q", i, e.Name, e.Kind)
+		}
+		if e.Message == "" {
+			addErr("errors[%d]: %s is missing a message", i, e.Name)
+		}
+		if e.Code != "" {
+			if prev, ok := codes[e.Code]; ok {
+				addErr("errors[%d]: %s has the same code %q as %s", i, e.Name, e.Code, prev)
+			}
+			codes[e.Code] = e.Name
+		}
+
+		params := make(map[string]bool, len(e.KVs))
+		for j, kv := range e.KVs {
+			param := paramName(kv.Key)
+			switch {
+			case kv.Key == "":
+				addErr("errors[%d].kvs[%d]: %s is missing a key", i, j, e.Name)
+			case !token.IsIdentifier(param) || token.IsKeyword(param):
+				addErr("errors[%d].kvs[%d]: key %q does not produce a valid param name", i, j, kv.Key)
+			case params[param] || param == "opts" || param == "errors":
+				addErr("errors[%d].kvs[%d]: key %q collides with another param", i, j, kv.Key)
+			}
+			params[param] = true
+
+			if kv.Type != "" {
+				if _, err := parser.ParseExpr(kv.Type); err != nil {
+					addErr("errors[%d].kvs[%d]: invalid type %q: %s", i, j, kv.Type, err)
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs, errors.NoFrame)
+}
+
+func isExportable(name string) bool {
+	return token.IsIdentifier(name) && token.IsExported(name)
+}
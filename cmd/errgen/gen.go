@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/jsteenb2/errors"
+)
+
+// codeKey is the KV key the error code is stored under. This
+// mirrors the err_kind field provided by errors.Fields.
+const codeKey = "err_code"
+
+func genGo(c Catalog, source string) ([]byte, error) {
+	var buf bytes.Buffer
+	err := goTmpl.Execute(&buf, struct {
+		Catalog
+		Source     string
+		CodeKey    string
+		Transports []string
+	}{
+		Catalog:    c,
+		Source:     source,
+		CodeKey:    codeKey,
+		Transports: c.transports(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute go template")
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to format generated go source")
+	}
+	return out, nil
+}
+
+func genMarkdown(c Catalog) ([]byte, error) {
+	var buf bytes.Buffer
+	err := mdTmpl.Execute(&buf, struct {
+		Catalog
+		Transports []string
+	}{
+		Catalog:    c,
+		Transports: c.transports(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute markdown template")
+	}
+	return buf.Bytes(), nil
+}
+
+// transports returns the sorted, unique set of transports referenced by
+// the status mappings of the catalog's kinds.
+func (c Catalog) transports() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, k := range c.Kinds {
+		for t := range k.Status {
+			if !seen[t] {
+				seen[t] = true
+				out = append(out, t)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+var funcs = template.FuncMap{
+	"goName":    goName,
+	"paramName": paramName,
+	"quote":     strconv.Quote,
+	"comment":   comment,
+	"mdEscape":  mdEscape,
+	"typeOf": func(kv KVDef) string {
+		if kv.Type == "" {
+			return "any"
+		}
+		return kv.Type
+	},
+	"status": func(k KindDef, transport string) string {
+		v, ok := k.Status[transport]
+		if !ok {
+			return ""
+		}
+		return strconv.Itoa(v)
+	},
+}
+
+var goTmpl = template.Must(template.New("go").Funcs(funcs).Parse(`// Code generated by errgen from {{ .Source }}. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"{{ .Import }}"
+)
+{{ if .Kinds }}
+const (
+{{- range .Kinds }}
+	// Kind{{ .Name }} is the {{ quote .Value }} error kind.
+	{{- if .Doc }}
+	//
+	{{ comment .Doc "\t" }}
+	{{- end }}
+	Kind{{ .Name }} = errors.Kind({{ quote .Value }})
+{{ end -}}
+)
+{{ end }}
+{{- $codeKey := .CodeKey }}
+{{- range .Errors }}{{ if .Code }}
+// Code{{ .Name }} is the error code for errors created by New{{ .Name }}.
+const Code{{ .Name }} = {{ quote .Code }}
+{{ end }}{{ end }}
+{{- range .Errors }}
+// New{{ .Name }} creates a new Kind{{ .Kind }} error with the msg {{ quote .Message }}.
+{{- if .Doc }}
+//
+{{ comment .Doc "" }}
+{{- end }}
+{{- range .KVs }}{{ if .Doc }}
+//   - {{ paramName .Key }}: {{ .Doc }}
+{{- end }}{{ end }}
+//
+// The opts are passed through to errors.New, allowing for additional
+// KVs, a wrapped error, or frame skips to be provided.
+func New{{ .Name }}({{ range .KVs }}{{ paramName .Key }} {{ typeOf . }}, {{ end }}opts ...any) error {
+	return errors.New({{ quote .Message }}, append([]any{
+		errors.SkipCaller,
+		Kind{{ .Kind }},
+		{{- if or .Code .KVs }}
+		errors.KVs(
+			{{- if .Code }}
+			{{ quote $codeKey }}, Code{{ .Name }},
+			{{- end }}
+			{{- range .KVs }}
+			{{ quote .Key }}, {{ paramName .Key }},
+			{{- end }}
+		),
+		{{- end }}
+	}, opts...)...)
+}
+{{ end }}
+{{- $kinds := .Kinds }}
+{{- range $t := .Transports }}
+// {{ goName $t }}Status returns the {{ $t }} status for the first Kind in the catalog
+// the err matches. When no Kind matches, false is returned.
+func {{ goName $t }}Status(err error) (int, bool) {
+	switch {
+	{{- range $kinds }}{{ $s := status . $t }}{{ if $s }}
+	case errors.Is(err, Kind{{ .Name }}):
+		return {{ $s }}, true
+	{{- end }}{{ end }}
+	}
+	return 0, false
+}
+{{ end }}`))
+
+var mdTmpl = template.Must(template.New("md").Funcs(funcs).Parse(`# {{ .Package }} errors
+
+<!-- Code generated by errgen. DO NOT EDIT. -->
+{{ $transports := .Transports }}
+## Kinds
+
+| Kind | Value |{{ range $transports }} {{ . }} |{{ end }} Description |
+|------|-------|{{ range $transports }}---|{{ end }}-------------|
+{{- range $k := .Kinds }}
+| ` + "`Kind{{ .Name }}`" + ` | ` + "`{{ .Value }}`" + ` |{{ range $transports }} {{ status $k . }} |{{ end }} {{ mdEscape .Doc }} |
+{{- end }}
+
+## Errors
+{{ range .Errors }}
+### {{ .Name }}
+
+{{ if .Doc }}{{ .Doc }}
+
+{{ end -}}
+- **Constructor:** ` + "`New{{ .Name }}`" + `
+- **Kind:** ` + "`Kind{{ .Kind }}`" + `
+{{- if .Code }}
+- **Code:** ` + "`{{ .Code }}`" + `
+{{- end }}
+- **Message:** {{ .Message }}
+{{- if .KVs }}
+
+| Key | Type | Description |
+|-----|------|-------------|
+{{- range .KVs }}
+| ` + "`{{ .Key }}`" + ` | ` + "`{{ typeOf . }}`" + ` | {{ mdEscape .Doc }} |
+{{- end }}
+{{- end }}
+{{ end -}}
+`))
+
+// initialisms are rendered in all caps when converting to go identifiers.
+var initialisms = map[string]bool{
+	"api": true, "db": true, "dns": true, "grpc": true, "http": true,
+	"id": true, "ip": true, "json": true, "rpc": true, "sql": true,
+	"tcp": true, "tls": true, "udp": true, "uri": true, "url": true,
+	"uuid": true, "xml": true,
+}
+
+// goName converts a snake, kebab or dotted name to an exported go
+// identifier (i.e. invoice_id => InvoiceID).
+func goName(s string) string {
+	var sb strings.Builder
+	for _, word := range splitWords(s) {
+		lower := strings.ToLower(word)
+		if initialisms[lower] {
+			sb.WriteString(strings.ToUpper(word))
+			continue
+		}
+		r := []rune(word)
+		r[0] = unicode.ToUpper(r[0])
+		sb.WriteString(string(r))
+	}
+	return sb.String()
+}
+
+// paramName converts a snake, kebab or dotted name to an unexported go
+// identifier (i.e. invoice_id => invoiceID).
+func paramName(s string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return ""
+	}
+
+	first := strings.ToLower(words[0])
+	return first + goName(strings.Join(words[1:], "_"))
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || unicode.IsSpace(r)
+	})
+}
+
+// comment renders the text as a go comment, prefixing each line with
+// the indent.
+func comment(text, indent string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(indent+"// "+l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func mdEscape(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "\n", " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGen(t *testing.T) {
+	c, err := loadCatalog("testdata/billing.yaml", "")
+	if err != nil {
+		t.Fatalf("unexpected error loading catalog: %s", err)
+	}
+
+	t.Run("go source matches golden file", func(t *testing.T) {
+		got, err := genGo(c, "billing.yaml")
+		if err != nil {
+			t.Fatalf("unexpected error generating go: %s", err)
+		}
+		eqGolden(t, "testdata/billing_gen.go.golden", got)
+	})
+
+	t.Run("markdown matches golden file", func(t *testing.T) {
+		got, err := genMarkdown(c)
+		if err != nil {
+			t.Fatalf("unexpected error generating markdown: %s", err)
+		}
+		eqGolden(t, "testdata/billing.md.golden", got)
+	})
+
+	t.Run("json catalog matches yaml catalog", func(t *testing.T) {
+		jc, err := loadCatalog("testdata/billing.json", "")
+		if err != nil {
+			t.Fatalf("unexpected error loading catalog: %s", err)
+		}
+
+		got, err := genGo(jc, "billing.yaml")
+		if err != nil {
+			t.Fatalf("unexpected error generating go: %s", err)
+		}
+		eqGolden(t, "testdata/billing_gen.go.golden", got)
+	})
+}
+
+func TestCatalog_validate(t *testing.T) {
+	c := Catalog{
+		Package: "billing",
+		Kinds: []KindDef{
+			{Name: "NotFound", Value: "not_found"},
+			{Name: "NotFound", Value: "not_found"},
+			{Name: "lower", Value: "lower"},
+		},
+		Errors: []ErrorDef{
+			{Name: "Missing", Kind: "Nope"},
+			{Name: "BadKV", Kind: "NotFound", Message: "bad kv", KVs: []KVDef{{Key: "type"}, {Key: "id", Type: "[[int"}}},
+		},
+	}
+
+	err := c.validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	for _, want := range []string{
+		`kinds[1]: duplicate kind name "NotFound"`,
+		`kinds[1]: NotFound has the same value "not_found" as NotFound`,
+		`kinds[2]: name "lower" must be a valid exported go identifier`,
+		`errors[0]: Missing references undefined kind "Nope"`,
+		`errors[0]: Missing is missing a message`,
+		`errors[1].kvs[0]: key "type" does not produce a valid param name`,
+		`errors[1].kvs[1]: invalid type "[[int"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing validation error:\n\t\twant:\t%s\n\t\tgot:\t%s", want, err)
+		}
+	}
+}
+
+func TestRun_pkgOverride(t *testing.T) {
+	t.Run("valid package name is used", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "errors_gen.go")
+
+		if err := run([]string{"-catalog", "testdata/billing.yaml", "-out", out, "-pkg", "invoices"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		src, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("unexpected error reading output: %s", err)
+		}
+		if !strings.Contains(string(src), "\npackage invoices\n") {
+			t.Errorf("package override missing from output:\n%s", src)
+		}
+	})
+
+	t.Run("invalid package name is rejected", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "errors_gen.go")
+
+		err := run([]string{"-catalog", "testdata/billing.yaml", "-out", out, "-pkg", "not-valid"})
+		if err == nil {
+			t.Fatal("expected validation error")
+		}
+		if want := `package "not-valid" is not a valid go package name`; !strings.Contains(err.Error(), want) {
+			t.Errorf("missing validation error:\n\t\twant:\t%s\n\t\tgot:\t%s", want, err)
+		}
+		if _, statErr := os.Stat(out); !os.IsNotExist(statErr) {
+			t.Errorf("expected no output to be written, got: %v", statErr)
+		}
+	})
+}
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		in, goName, param string
+	}{
+		{in: "invoice_id", goName: "InvoiceID", param: "invoiceID"},
+		{in: "http", goName: "HTTP", param: "http"},
+		{in: "retry-after.seconds", goName: "RetryAfterSeconds", param: "retryAfterSeconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := goName(tt.in); got != tt.goName {
+				t.Errorf("unexpected go name:\n\t\twant:\t%s\n\t\tgot:\t%s", tt.goName, got)
+			}
+			if got := paramName(tt.in); got != tt.param {
+				t.Errorf("unexpected param name:\n\t\twant:\t%s\n\t\tgot:\t%s", tt.param, got)
+			}
+		})
+	}
+}
+
+func eqGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %s", err)
+		}
+	}
+
+	want, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		t.Fatalf("failed to read golden file: %s", err)
+	}
+	if string(want) != string(got) {
+		t.Errorf("generated output does not match %s:\n\t\twant:\n%s\n\t\tgot:\n%s", path, want, got)
+	}
+}
@@ -0,0 +1,88 @@
+// Command errgen generates errors.Kind constants, typed error constructors
+// and a Markdown reference from a declarative YAML or JSON error catalog.
+// Keeping the catalog as the source of truth, keeps the kinds, codes and
+// messages of a package consistent across teams. Intended for use with
+// go generate:
+//
+//	//go:generate go run github.com/jsteenb2/errors/cmd/errgen -catalog errors.yaml -out errors_gen.go -md ERRORS.md
+//
+// A catalog looks like the following:
+//
+//	package: billing
+//	kinds:
+//	  - name: NotFound
+//	    value: not_found
+//	    doc: the requested entity does not exist.
+//	    status:
+//	      http: 404
+//	errors:
+//	  - name: InvoiceNotFound
+//	    kind: NotFound
+//	    code: BILL-404
+//	    message: invoice not found
+//	    kvs:
+//	      - key: invoice_id
+//	        type: string
+//
+// Which generates a KindNotFound constant, a CodeInvoiceNotFound constant,
+// a NewInvoiceNotFound(invoiceID string, opts ...any) error constructor and
+// a HTTPStatus(err error) (int, bool) func for the status mapping.
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/jsteenb2/errors"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		errors.Fprint(os.Stderr, err, errors.PrintOpts{Prefix: "errgen: "})
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("errgen", flag.ContinueOnError)
+	var (
+		catalogPath = fs.String("catalog", "", "path to the YAML or JSON error catalog (required)")
+		outPath     = fs.String("out", "errors_gen.go", "path to write the generated go source to")
+		mdPath      = fs.String("md", "", "path to write the generated Markdown reference to, skipped when empty")
+		pkg         = fs.String("pkg", "", "overrides the package name defined in the catalog")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *catalogPath == "" {
+		fs.Usage()
+		return errors.New("the -catalog flag is required")
+	}
+
+	c, err := loadCatalog(*catalogPath, *pkg)
+	if err != nil {
+		return err
+	}
+
+	src, err := genGo(c, filepath.Base(*catalogPath))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		return errors.Wrap(err, "failed to write go source")
+	}
+
+	if *mdPath == "" {
+		return nil
+	}
+
+	md, err := genMarkdown(c)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*mdPath, md, 0o644); err != nil {
+		return errors.Wrap(err, "failed to write markdown reference")
+	}
+	return nil
+}
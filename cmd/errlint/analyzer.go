@@ -0,0 +1,314 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const errorsPath = "github.com/jsteenb2/errors"
+
+// Analyzer reports misuse of the github.com/jsteenb2/errors package.
+var Analyzer = &analysis.Analyzer{
+	Name:     "errlint",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const doc = `report misuse of the github.com/jsteenb2/errors package
+
+The errlint analyzer reports the following:
+  - odd argument counts or non-string keys passed to errors.KVs
+  - discarded results of errors.New, errors.Wrap and errors.Join
+  - fmt.Errorf with %w in files that use errors.Wrap for wrapping
+  - errors.Kind conversions that are not declared as constants
+  - option types passed to errors.New, errors.Wrap and errors.Join
+    that are silently ignored`
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.File)(nil),
+		(*ast.ExprStmt)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	var errorsName string // local name of the errors pkg in the current file
+	insp.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch n := n.(type) {
+		case *ast.File:
+			errorsName = importName(n, errorsPath)
+		case *ast.ExprStmt:
+			checkDiscarded(pass, n.X)
+		case *ast.CallExpr:
+			if isKindConversion(pass, n) {
+				checkKindConst(pass, n, stack)
+				return true
+			}
+
+			fn, ok := typeutil.Callee(pass.TypesInfo, n).(*types.Func)
+			if !ok || fn.Pkg() == nil {
+				return true
+			}
+			switch path, name := fn.Pkg().Path(), fn.Name(); {
+			case path == errorsPath && name == "KVs":
+				checkKVs(pass, n)
+			case path == errorsPath && (name == "New" || name == "Wrap"):
+				checkOpts(pass, name, n.Args[1:], n.Ellipsis.IsValid(), false)
+			case path == errorsPath && name == "Join":
+				checkOpts(pass, name, n.Args, n.Ellipsis.IsValid(), true)
+			case path == "fmt" && name == "Errorf" && errorsName != "":
+				checkErrorf(pass, n, errorsName)
+			}
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+func checkDiscarded(pass *analysis.Pass, expr ast.Expr) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	if name, ok := errorsFunc(pass, call); ok && (name == "New" || name == "Wrap" || name == "Join") {
+		pass.Reportf(call.Pos(), "result of errors.%s is discarded", name)
+	}
+}
+
+func checkKVs(pass *analysis.Pass, call *ast.CallExpr) {
+	if call.Ellipsis.IsValid() {
+		return // spread args are not knowable statically
+	}
+
+	for i := 0; i < len(call.Args); i++ {
+		arg := call.Args[i]
+		t := pass.TypesInfo.TypeOf(arg)
+		if t == nil {
+			return
+		}
+		if isErrorsType(t, "KV") || isSliceOf(t, "KV") {
+			continue // KV and []KV are taken as is
+		}
+
+		if !isValidKey(t) {
+			pass.Reportf(arg.Pos(), "errors.KVs key must be a string or fmt.Stringer, got %s", t)
+		}
+		if i+1 >= len(call.Args) {
+			pass.Reportf(arg.Pos(), "errors.KVs called with odd number of key value arguments, key %s is missing a value", types.ExprString(arg))
+		}
+		i++ // skip the value
+	}
+}
+
+func isValidKey(t types.Type) bool {
+	if basic, ok := t.(*types.Basic); ok && (basic.Kind() == types.String || basic.Kind() == types.UntypedString) {
+		return true
+	}
+	if types.IsInterface(t) && !implementsStringer(t) {
+		// an interface key may hold a string, we can't know statically
+		return types.NewMethodSet(t).Len() == 0
+	}
+	return implementsStringer(t)
+}
+
+func implementsStringer(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "String")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	basic, ok := sig.Results().At(0).Type().(*types.Basic)
+	return ok && basic.Kind() == types.String
+}
+
+func checkOpts(pass *analysis.Pass, fnName string, args []ast.Expr, spread, join bool) {
+	for _, arg := range args {
+		t := pass.TypesInfo.TypeOf(arg)
+		if t == nil {
+			continue
+		}
+		if spread {
+			slc, ok := t.Underlying().(*types.Slice)
+			if !ok {
+				continue
+			}
+			t = slc.Elem()
+		}
+		if isSupportedOpt(t, join) {
+			continue
+		}
+
+		msg := fmt.Sprintf("errors.%s ignores options of type %s", fnName, t)
+		if sig, ok := t.Underlying().(*types.Signature); ok && join && isJoinFormatSig(sig) {
+			msg += ", convert it to an errors.JoinFormatFn"
+		}
+		pass.Reportf(arg.Pos(), "%s", msg)
+	}
+}
+
+func isSupportedOpt(t types.Type, join bool) bool {
+	if basic, ok := t.(*types.Basic); ok {
+		switch basic.Kind() {
+		case types.String, types.UntypedString, types.UntypedNil:
+			return true
+		}
+		return false
+	}
+
+	for _, name := range []string{"FrameSkips", "Kind", "KV", "ClassifyOpt", "Remediation", "UserMessage"} {
+		if isErrorsType(t, name) {
+			return true
+		}
+	}
+	if isSliceOf(t, "KV") {
+		return true
+	}
+	if types.Implements(t, errorType) {
+		return true
+	}
+	if join && isErrorSlice(t) {
+		return true
+	}
+	for _, name := range []string{"JoinFormatFn", "JoinFlatten", "JoinDedup"} {
+		if join && isErrorsType(t, name) {
+			return true
+		}
+	}
+
+	// an interface may hold any of the supported types, which we can't
+	// know statically, so we give it the benefit of the doubt
+	return types.IsInterface(t)
+}
+
+func isJoinFormatSig(sig *types.Signature) bool {
+	if sig.Params().Len() != 2 || sig.Results().Len() != 1 {
+		return false
+	}
+	str := types.Typ[types.String]
+	return types.Identical(sig.Params().At(0).Type(), str) &&
+		isErrorSlice(sig.Params().At(1).Type()) &&
+		types.Identical(sig.Results().At(0).Type(), str)
+}
+
+func checkKindConst(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	if len(call.Args) != 1 {
+		return
+	}
+	if tv := pass.TypesInfo.Types[call.Args[0]]; tv.Value == nil {
+		return // dynamic kinds are deliberate
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		if decl, ok := stack[i].(*ast.GenDecl); ok && decl.Tok == token.CONST {
+			return
+		}
+	}
+	pass.Reportf(call.Pos(), "errors.Kind %s should be declared as a constant", types.ExprString(call.Args[0]))
+}
+
+func checkErrorf(pass *analysis.Pass, call *ast.CallExpr, errorsName string) {
+	if len(call.Args) == 0 {
+		return
+	}
+	tv := pass.TypesInfo.Types[call.Args[0]]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+	format := constant.StringVal(tv.Value)
+	if !strings.Contains(format, "%w") {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "fmt.Errorf with %w loses the stack frame and fields, use errors.Wrap instead",
+	}
+
+	// only the simplest of cases can be fixed mechanically: "msg: %w", err
+	if msg, ok := strings.CutSuffix(format, ": %w"); ok && len(call.Args) == 2 && !strings.Contains(msg, "%") {
+		fix := fmt.Sprintf("%s.Wrap(%s, %s)", errorsName, types.ExprString(call.Args[1]), strconv.Quote(msg))
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Replace with " + errorsName + ".Wrap",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     call.Pos(),
+				End:     call.End(),
+				NewText: []byte(fix),
+			}},
+		}}
+	}
+	pass.Report(diag)
+}
+
+func errorsFunc(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != errorsPath {
+		return "", false
+	}
+	return fn.Name(), true
+}
+
+func isKindConversion(pass *analysis.Pass, call *ast.CallExpr) bool {
+	tv, ok := pass.TypesInfo.Types[call.Fun]
+	return ok && tv.IsType() && isErrorsType(tv.Type, "Kind")
+}
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+func isErrorsType(t types.Type, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == errorsPath && obj.Name() == name
+}
+
+func isSliceOf(t types.Type, name string) bool {
+	slc, ok := t.(*types.Slice)
+	return ok && isErrorsType(slc.Elem(), name)
+}
+
+func isErrorSlice(t types.Type) bool {
+	slc, ok := t.(*types.Slice)
+	return ok && types.Identical(slc.Elem(), types.Universe.Lookup("error").Type())
+}
+
+// importName returns the name the file refers to the import path by. An
+// empty string is returned when the file does not import the path.
+func importName(f *ast.File, path string) string {
+	for _, imp := range f.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p != path {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name == "_" || imp.Name.Name == "." {
+				return ""
+			}
+			return imp.Name.Name
+		}
+		return "errors"
+	}
+	return ""
+}
@@ -0,0 +1,14 @@
+// Command errlint reports misuse of the github.com/jsteenb2/errors package.
+// It can be run standalone, or as a vet tool:
+//
+//	go install github.com/jsteenb2/errors/cmd/errlint@latest
+//	go vet -vettool=$(which errlint) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(Analyzer)
+}
@@ -0,0 +1,55 @@
+package a
+
+import (
+	"fmt"
+
+	"github.com/jsteenb2/errors"
+)
+
+const KindInvalid = errors.Kind("invalid")
+
+var kindVar = errors.Kind("var") // want `errors.Kind "var" should be declared as a constant`
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+type myStr string
+
+func kvs(dynamicKind string, opts []any, kvs []any) {
+	_ = errors.KVs("k1", 1, "k2") // want `errors.KVs called with odd number of key value arguments, key "k2" is missing a value`
+	_ = errors.KVs(1, "v1")       // want `errors.KVs key must be a string or fmt.Stringer, got int`
+	_ = errors.KVs(stringer{}, "v1", errors.KV{K: "k", V: 1}, errors.KVs("k2", 2), "k3", 3)
+	_ = errors.KVs(kvs...)
+
+	_ = errors.New("msg", errors.Kind(dynamicKind))
+	_ = errors.New("msg", errors.Kind("inline")) // want `errors.Kind "inline" should be declared as a constant`
+	_ = errors.New("msg", opts...)
+}
+
+func discarded(err error) {
+	errors.Wrap(err)            // want `result of errors.Wrap is discarded`
+	_ = errors.Wrap(err, "msg") // explicitly discarded
+	errors.New("msg")           // want `result of errors.New is discarded`
+	(errors.Join(err))          // want `result of errors.Join is discarded`
+	if wrapped := errors.Wrap(err); wrapped != nil {
+		_ = wrapped
+	}
+}
+
+func errorf(err error) error {
+	if err != nil {
+		return fmt.Errorf("ctx: %w", err) // want `fmt.Errorf with %w loses the stack frame and fields, use errors.Wrap instead`
+	}
+	return fmt.Errorf("no wrap %d", 1)
+}
+
+func opts(err error, ms myStr, ia any, kvs []errors.KV, errs []error) error {
+	_ = errors.New("msg", KindInvalid, errors.SkipCaller, errors.KVs("k", "v"), kvs, errors.KV{K: "k"}, err, nil, ia, "str")
+	_ = errors.New("msg", 3)                                                   // want `errors.New ignores options of type int`
+	_ = errors.Wrap(err, ms)                                                   // want `errors.Wrap ignores options of type a.myStr`
+	_ = errors.Join(err, errs)                                                 // ok, joins take []error
+	_ = errors.Wrap(err, errs)                                                 // want `errors.Wrap ignores options of type \[\]error`
+	_ = errors.Join(err, func(msg string, errs []error) string { return msg }) // want `errors.Join ignores options of type func\(msg string, errs \[\]error\) string, convert it to an errors.JoinFormatFn`
+	return errors.Join(err, errors.JoinFormatFn(func(msg string, errs []error) string { return msg }))
+}
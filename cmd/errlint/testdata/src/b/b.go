@@ -0,0 +1,8 @@
+package b
+
+import "fmt"
+
+// b does not use the errors pkg, so fmt.Errorf with %w is left as is.
+func errorf(err error) error {
+	return fmt.Errorf("ctx: %w", err)
+}
@@ -0,0 +1,28 @@
+// Package errors is a stub of github.com/jsteenb2/errors for the analyzer tests.
+package errors
+
+type FrameSkips int
+
+const (
+	NoFrame    FrameSkips = -1
+	SkipCaller FrameSkips = 1
+)
+
+type Kind string
+
+func (k Kind) Error() string { return string(k) }
+
+type KV struct {
+	K string
+	V any
+}
+
+type JoinFormatFn func(msg string, errs []error) string
+
+func KVs(fields ...any) []KV { return nil }
+
+func New(msg string, opts ...any) error { return nil }
+
+func Wrap(err error, opts ...any) error { return err }
+
+func Join(opts ...any) error { return nil }
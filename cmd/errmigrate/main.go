@@ -0,0 +1,175 @@
+// Command errmigrate mechanically rewrites go source to use the
+// github.com/jsteenb2/errors module. The following are rewritten:
+//
+//   - github.com/pkg/errors: New, Errorf, Wrap, Wrapf, WithStack, WithMessage,
+//     WithMessagef, Cause, Is, As and Unwrap map to their errors.New/errors.Wrap
+//     equivalents, with formatted messages passed through fmt.Sprintf.
+//   - fmt.Errorf("ctx: %w", err) becomes errors.Wrap(err, "ctx").
+//   - github.com/hashicorp/go-multierror: Append becomes errors.Join with the
+//     errors.Flatten option, keeping the joined errors a flat list.
+//   - the std lib errors import is replaced with this module. Join is provided
+//     the errors.NewlineFormat option, keeping the std lib's Error output.
+//
+// Usages that have no equivalent, (i.e. pkg/errors.StackTrace or the
+// *multierror.Error type) are reported, and the import they belong to
+// is left untouched in that file so that the result continues to compile.
+//
+// Note, errors.Wrap returns nil when wrapping a nil error, where fmt.Errorf
+// does not. Review the rewrites with your diff tool of choice:
+//
+//	errmigrate -w ./... && git diff
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jsteenb2/errors"
+	"golang.org/x/tools/imports"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		errors.Fprint(os.Stderr, err, errors.PrintOpts{Prefix: "errmigrate: "})
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("errmigrate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: errmigrate [flags] [path ...]\n")
+		flags.PrintDefaults()
+	}
+	var (
+		write = flags.Bool("w", false, "write the result to the source file instead of stdout")
+		list  = flags.Bool("l", false, "list the files that would be rewritten")
+	)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var files []string
+	for _, p := range paths {
+		found, err := goFiles(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, found...)
+	}
+
+	for _, f := range files {
+		if err := migrateFile(f, *write, *list); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrateFile(path string, write, list bool) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err)
+	}
+
+	out, notes, err := migrateSource(path, src)
+	if err != nil {
+		return err
+	}
+	for _, n := range notes {
+		fmt.Fprintln(os.Stderr, n)
+	}
+	if bytes.Equal(src, out) {
+		return nil
+	}
+
+	switch {
+	case list:
+		fmt.Println(path)
+	case write:
+		info, err := os.Stat(path)
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		if err := os.WriteFile(path, out, info.Mode().Perm()); err != nil {
+			return errors.Wrap(err, "failed to write migrated file", errors.KVs("path", path))
+		}
+	default:
+		os.Stdout.Write(out)
+	}
+	return nil
+}
+
+func migrateSource(path string, src []byte) ([]byte, []note, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse file")
+	}
+
+	changed, notes := migrate(fset, file)
+	if !changed {
+		return src, notes, nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to format migrated file", errors.KVs("path", path))
+	}
+
+	// regroup the imports, so the added module import lands outside the std lib group
+	out, err := imports.Process(path, buf.Bytes(), &imports.Options{FormatOnly: true, Comments: true, TabIndent: true, TabWidth: 8})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to format migrated file imports", errors.KVs("path", path))
+	}
+	return out, notes, nil
+}
+
+// goFiles returns the go files for the path. A path ending in /... is
+// walked recursively, skipping vendor and testdata dirs.
+func goFiles(path string) ([]string, error) {
+	root, recursive := strings.CutSuffix(path, "/...")
+	if root == "" {
+		root = "."
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if !info.IsDir() {
+		return []string{root}, nil
+	}
+
+	var out []string
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if p != root && (!recursive || name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(p, ".go") {
+			out = append(out, p)
+		}
+		return nil
+	})
+	return out, errors.Wrap(err)
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+const (
+	modulePath     = "github.com/jsteenb2/errors"
+	stdErrorsPath  = "errors"
+	pkgErrorsPath  = "github.com/pkg/errors"
+	multierrorPath = "github.com/hashicorp/go-multierror"
+	fmtPath        = "fmt"
+)
+
+// note is a usage that could not be migrated mechanically and requires
+// a human to convert it.
+type note struct {
+	pos token.Position
+	msg string
+}
+
+func (n note) String() string {
+	return fmt.Sprintf("%s: %s", n.pos, n.msg)
+}
+
+// migrator rewrites a single file.
+type migrator struct {
+	fset *token.FileSet
+	file *ast.File
+
+	// local names of the imports in the file, empty when not imported
+	stdErrors, pkgErrors, multierror, fmtName string
+	// target is the local name of this module in the rewritten file
+	target string
+
+	// skip holds the local names of imports with usages that can't be
+	// migrated, these imports are left untouched.
+	skip map[string]bool
+
+	changed bool
+	notes   []note
+}
+
+// migrate rewrites the file in place to use this module. It returns true
+// when the file was modified, along with any usages that could not be
+// migrated.
+func migrate(fset *token.FileSet, file *ast.File) (bool, []note) {
+	m := migrator{
+		fset:       fset,
+		file:       file,
+		stdErrors:  importName(file, stdErrorsPath),
+		pkgErrors:  importName(file, pkgErrorsPath),
+		multierror: importName(file, multierrorPath),
+		fmtName:    importName(file, fmtPath),
+		target:     importName(file, modulePath),
+		skip:       make(map[string]bool),
+	}
+	if m.target == "" {
+		m.target = "errors"
+	}
+
+	m.scan()
+	for _, name := range []string{m.stdErrors, m.pkgErrors} {
+		if name != "" && m.skip[name] && name == m.target {
+			m.report(file.Name, fmt.Sprintf("file must continue to import %q as %s, migrate it manually", pathOf(file, name), name))
+			return false, m.notes
+		}
+	}
+
+	astutil.Apply(file, nil, func(c *astutil.Cursor) bool {
+		switch n := c.Node().(type) {
+		case *ast.CallExpr:
+			if repl := m.rewriteCall(n); repl != nil {
+				c.Replace(repl)
+				m.changed = true
+			}
+		case *ast.SelectorExpr:
+			// selectors that are not called, (i.e. errors.Is passed
+			// as a func value) are renamed in place.
+			if isCallFun(c) {
+				break
+			}
+			if m.rewriteSelector(n) {
+				m.changed = true
+			}
+		}
+		return true
+	})
+
+	if !m.changed {
+		return false, m.notes
+	}
+	m.fixImports()
+	return true, m.notes
+}
+
+func (m *migrator) rewriteCall(call *ast.CallExpr) ast.Expr {
+	pkg, fn, ok := m.selector(call.Fun)
+	if !ok || m.skip[pkg] {
+		return nil
+	}
+
+	switch pkg {
+	case m.stdErrors:
+		if fn == "Join" {
+			// the std lib joins the messages with newlines, where the
+			// default format of this pkg is a bulleted list
+			newline := &ast.SelectorExpr{
+				X:   &ast.Ident{NamePos: call.Rparen, Name: m.target},
+				Sel: ast.NewIdent("NewlineFormat"),
+			}
+			return m.call(call, fn, append(call.Args[:len(call.Args):len(call.Args)], newline)...)
+		}
+		return m.call(call, fn, call.Args...)
+	case m.pkgErrors:
+		return m.rewritePkgErrors(call, fn)
+	case m.multierror:
+		if fn == "Append" {
+			// multierror.Append accumulates a flat list of errors, where
+			// repeatedly joining would nest a join for every append
+			flatten := &ast.SelectorExpr{
+				X:   &ast.Ident{NamePos: call.Rparen, Name: m.target},
+				Sel: ast.NewIdent("Flatten"),
+			}
+			return m.call(call, "Join", append(call.Args[:len(call.Args):len(call.Args)], flatten)...)
+		}
+	case m.fmtName:
+		if fn == "Errorf" {
+			return m.rewriteErrorf(call)
+		}
+	}
+	return nil
+}
+
+// migratable is the set of funcs that can be migrated for each import. The
+// value marks funcs that can only be migrated when called, (i.e. Wrapf)
+// rather than referenced as a func value.
+var migratable = map[string]map[string]bool{
+	stdErrorsPath: {"As": false, "Is": false, "Join": false, "New": false, "Unwrap": false},
+	pkgErrorsPath: {
+		"As": false, "Cause": false, "Is": false, "New": false, "Unwrap": false, "Wrap": false,
+		"Errorf": true, "WithMessage": true, "WithMessagef": true, "WithStack": true, "Wrapf": true,
+	},
+	multierrorPath: {"Append": true},
+}
+
+// scan marks the imports that have usages which can't be migrated.
+func (m *migrator) scan() {
+	astutil.Apply(m.file, func(c *astutil.Cursor) bool {
+		sel, ok := c.Node().(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, name, ok := m.selector(sel)
+		if !ok || pkg == m.fmtName {
+			return true
+		}
+
+		callOnly, ok := migratable[pathOf(m.file, pkg)][name]
+		switch {
+		case !ok:
+			m.report(sel, fmt.Sprintf("%s.%s has no equivalent, %q is left as is", pkg, name, pathOf(m.file, pkg)))
+			m.skip[pkg] = true
+		case callOnly && !isCallFun(c):
+			m.report(sel, fmt.Sprintf("%s.%s is only migrated when called, %q is left as is", pkg, name, pathOf(m.file, pkg)))
+			m.skip[pkg] = true
+		}
+		return true
+	}, nil)
+}
+
+func (m *migrator) rewritePkgErrors(call *ast.CallExpr, fn string) ast.Expr {
+	args := call.Args
+	if call.Ellipsis.IsValid() && fn != "Errorf" && fn != "Wrapf" && fn != "WithMessagef" {
+		return nil
+	}
+
+	switch fn {
+	case "New", "Cause", "Is", "As", "Unwrap", "Wrap":
+		return m.call(call, fn, args...)
+	case "WithStack":
+		return m.call(call, "Wrap", args...)
+	case "WithMessage":
+		return m.call(call, "Wrap", args...)
+	case "Errorf":
+		if len(args) == 1 {
+			return m.call(call, "New", args...)
+		}
+		return m.call(call, "New", m.sprintf(call, args, call.Ellipsis))
+	case "Wrapf", "WithMessagef":
+		if len(args) < 2 {
+			return nil
+		}
+		if len(args) == 2 {
+			return m.call(call, "Wrap", args...)
+		}
+		return m.call(call, "Wrap", args[0], m.sprintf(call, args[1:], call.Ellipsis))
+	}
+	return nil
+}
+
+// rewriteErrorf rewrites fmt.Errorf calls that wrap an error as the final
+// verb, i.e. fmt.Errorf("ctx: %w", err). All other forms are left as is.
+func (m *migrator) rewriteErrorf(call *ast.CallExpr) ast.Expr {
+	if len(call.Args) < 2 || call.Ellipsis.IsValid() {
+		return nil
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil
+	}
+	format, err := strconv.Unquote(lit.Value)
+	if err != nil || !strings.Contains(format, "%w") {
+		return nil
+	}
+
+	var msg string
+	switch {
+	case format == "%w":
+	case strings.HasSuffix(format, ": %w"):
+		msg = strings.TrimSuffix(format, ": %w")
+	default:
+		m.report(call, "fmt.Errorf with %w not at the end of the format string requires a manual migration")
+		return nil
+	}
+	if strings.Contains(msg, "%w") {
+		m.report(call, "fmt.Errorf with multiple %w verbs requires a manual migration, see errors.Join")
+		return nil
+	}
+
+	errArg, rest := call.Args[len(call.Args)-1], call.Args[1:len(call.Args)-1]
+	switch {
+	case msg == "":
+		return m.call(call, "Wrap", errArg)
+	case len(rest) == 0 && !strings.Contains(msg, "%"):
+		return m.call(call, "Wrap", errArg, &ast.BasicLit{ValuePos: lit.Pos(), Kind: token.STRING, Value: strconv.Quote(msg)})
+	default:
+		msgLit := &ast.BasicLit{ValuePos: lit.Pos(), Kind: token.STRING, Value: strconv.Quote(msg)}
+		return m.call(call, "Wrap", errArg, m.sprintf(call, append([]ast.Expr{msgLit}, rest...), token.NoPos))
+	}
+}
+
+func (m *migrator) rewriteSelector(sel *ast.SelectorExpr) bool {
+	pkg, _, ok := m.selector(sel)
+	if !ok || m.skip[pkg] || pkg == m.fmtName || pkg == m.multierror {
+		return false
+	}
+	sel.X.(*ast.Ident).Name = m.target
+	return true
+}
+
+// selector returns the package and selected name of pkg.Name expressions
+// that refer to an import of the file.
+func (m *migrator) selector(expr ast.Expr) (string, string, bool) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok || ident.Obj != nil || ident.Name == "" {
+		// a non nil Obj is a local declaration shadowing the import name
+		return "", "", false
+	}
+
+	switch ident.Name {
+	case m.stdErrors, m.pkgErrors, m.multierror, m.fmtName:
+		return ident.Name, sel.Sel.Name, true
+	}
+	return "", "", false
+}
+
+func (m *migrator) call(orig *ast.CallExpr, fn string, args ...ast.Expr) *ast.CallExpr {
+	call := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   &ast.Ident{NamePos: orig.Fun.Pos(), Name: m.target},
+			Sel: ast.NewIdent(fn),
+		},
+		Lparen: orig.Lparen,
+		Args:   args,
+		Rparen: orig.Rparen,
+	}
+
+	// the spread is kept only when the original args are passed through
+	// as is. Join is the exception, it accepts a []error and can't take
+	// a spread []error as its ...any.
+	passThrough := len(args) > 0 && len(orig.Args) > 0 && args[len(args)-1] == orig.Args[len(orig.Args)-1]
+	if passThrough && fn != "Join" {
+		call.Ellipsis = orig.Ellipsis
+	}
+	return call
+}
+
+func (m *migrator) sprintf(orig *ast.CallExpr, args []ast.Expr, ellipsis token.Pos) *ast.CallExpr {
+	if m.fmtName == "" {
+		m.fmtName = "fmt"
+	}
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   &ast.Ident{NamePos: args[0].Pos(), Name: m.fmtName},
+			Sel: ast.NewIdent("Sprintf"),
+		},
+		Lparen:   args[0].Pos(),
+		Args:     args,
+		Ellipsis: ellipsis,
+		Rparen:   orig.Rparen,
+	}
+}
+
+func (m *migrator) fixImports() {
+	remove := func(path, name string) {
+		if name != "" && !m.skip[name] {
+			astutil.DeleteNamedImport(m.fset, m.file, importAlias(m.file, path), path)
+		}
+	}
+	remove(stdErrorsPath, m.stdErrors)
+	remove(pkgErrorsPath, m.pkgErrors)
+	remove(multierrorPath, m.multierror)
+
+	if m.target == "errors" {
+		astutil.AddImport(m.fset, m.file, modulePath)
+	} else {
+		astutil.AddNamedImport(m.fset, m.file, m.target, modulePath)
+	}
+	switch {
+	case usesName(m.file, m.fmtName) && m.fmtName == "fmt":
+		astutil.AddImport(m.fset, m.file, fmtPath)
+	case usesName(m.file, m.fmtName):
+		astutil.AddNamedImport(m.fset, m.file, m.fmtName, fmtPath)
+	case m.fmtName != "":
+		astutil.DeleteNamedImport(m.fset, m.file, importAlias(m.file, fmtPath), fmtPath)
+	}
+}
+
+func (m *migrator) report(n ast.Node, msg string) {
+	m.notes = append(m.notes, note{pos: m.fset.Position(n.Pos()), msg: msg})
+}
+
+// importName returns the name the file refers to the import path by. An
+// empty string is returned when the file does not import the path.
+func importName(f *ast.File, path string) string {
+	for _, imp := range f.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p != path {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name == "_" || imp.Name.Name == "." {
+				return ""
+			}
+			return imp.Name.Name
+		}
+		switch path {
+		case multierrorPath:
+			return "multierror"
+		case pkgErrorsPath, modulePath:
+			return "errors"
+		}
+		return path[strings.LastIndex(path, "/")+1:]
+	}
+	return ""
+}
+
+// pathOf returns the import path of the local package name.
+func pathOf(f *ast.File, name string) string {
+	for _, imp := range f.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		if importName(f, p) == name {
+			return p
+		}
+	}
+	return ""
+}
+
+func isCallFun(c *astutil.Cursor) bool {
+	_, ok := c.Parent().(*ast.CallExpr)
+	return ok && c.Name() == "Fun"
+}
+
+// importAlias returns the explicit name of the import, if any.
+func importAlias(f *ast.File, path string) string {
+	for _, imp := range f.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p == path && imp.Name != nil {
+			return imp.Name.Name
+		}
+	}
+	return ""
+}
+
+// usesName reports whether the file refers to the package name in a
+// selector expression.
+func usesName(f *ast.File, name string) bool {
+	if name == "" {
+		return false
+	}
+
+	var used bool
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || used {
+			return !used
+		}
+		if ident, ok := sel.X.(*ast.Ident); ok && ident.Obj == nil && ident.Name == name {
+			used = true
+		}
+		return true
+	})
+	return used
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name      string
+		wantNotes []string
+	}{
+		{name: "pkgerrors"},
+		{
+			name:      "errorf",
+			wantNotes: []string{"errorf.input:23:9: fmt.Errorf with %w not at the end of the format string requires a manual migration"},
+		},
+		{name: "multierror"},
+		{
+			name: "unsupported",
+			wantNotes: []string{
+				`unsupported.input:11:14: multierror.Error has no equivalent, "github.com/hashicorp/go-multierror" is left as is`,
+				`unsupported.input:15:23: errors.ErrUnsupported has no equivalent, "errors" is left as is`,
+				`unsupported.input:1:9: file must continue to import "errors" as errors, migrate it manually`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inPath := filepath.Join("testdata", tt.name+".input")
+			src, err := os.ReadFile(inPath)
+			if err != nil {
+				t.Fatalf("failed to read input: %s", err)
+			}
+
+			got, notes, err := migrateSource(inPath, src)
+			if err != nil {
+				t.Fatalf("unexpected error migrating source: %s", err)
+			}
+
+			goldenPath := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %s", err)
+				}
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %s", err)
+			}
+			if string(want) != string(got) {
+				t.Errorf("migrated source does not match %s:\n\t\twant:\n%s\n\t\tgot:\n%s", goldenPath, want, got)
+			}
+
+			var gotNotes []string
+			for _, n := range notes {
+				gotNotes = append(gotNotes, strings.TrimPrefix(n.String(), "testdata/"))
+			}
+			if strings.Join(tt.wantNotes, "\n") != strings.Join(gotNotes, "\n") {
+				t.Errorf("unexpected notes:\n\t\twant:\t%q\n\t\tgot:\t%q", tt.wantNotes, gotNotes)
+			}
+		})
+	}
+}
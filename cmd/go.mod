@@ -0,0 +1,13 @@
+module github.com/jsteenb2/errors/cmd
+
+go 1.22.0
+
+require github.com/jsteenb2/errors v0.0.0-20261018235957-103f61ef4463
+
+require gopkg.in/yaml.v3 v3.0.1
+
+require (
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+	golang.org/x/tools v0.30.0
+)
@@ -4,11 +4,13 @@ import (
 	"cmp"
 	"fmt"
 	"io"
+	"slices"
 )
 
 func newE(opts ...any) error {
 	var err e
 
+	var classify ClassifyOpt
 	skipFrames := FrameSkips(3)
 	for _, o := range opts {
 		if o == nil {
@@ -25,20 +27,47 @@ func newE(opts ...any) error {
 			}
 		case Kind:
 			err.kind = arg
+		case ClassifyOpt:
+			classify = arg
 		case KV:
 			err.kvs = append(err.kvs, arg)
 		case []KV:
 			err.kvs = append(err.kvs, arg...)
+		case Remediation:
+			err.hints = append(err.hints, arg)
+		case UserMessage:
+			err.userMsg = string(arg)
 		case error:
 			err.wrappedErr = arg
 		}
 	}
+	switch err.wrappedErr.(type) {
+	case nil, *e, *joinE, chain:
+		// only foreign errors are subject to the registered rules
+	default:
+		if r, ok := matchRule(err.wrappedErr); ok {
+			err.applyRule(r)
+		}
+	}
+	if classify && err.kind == "" && getKind(err.wrappedErr) == "" {
+		err.kind = classifyKind(err.wrappedErr)
+	}
 	if frame, ok := getFrame(skipFrames); ok {
 		err.frame = frame
 	}
 	return &err
 }
 
+func (err *e) applyRule(r Rule) {
+	if err.kind == "" && getKind(err.wrappedErr) == "" {
+		err.kind = r.Kind
+	}
+	if r.Code != "" {
+		err.kvs = append(err.kvs, KV{K: "err_code", V: r.Code})
+	}
+	err.kvs = append(err.kvs, r.KVs...)
+}
+
 // e represents the internal error. We do not expose this error to avoid
 // hyrum's law. We wish for folks to sink their teeth into the behavior
 // of this error, via the exported funcs, or w/e interface a consumer
@@ -60,6 +89,9 @@ type e struct {
 	//	   calling something like Meta/Fields on the error? Then have a specific
 	//	   function for getting the logging fields (i.e. everything to []any)
 	kvs []KV
+
+	hints   []Remediation
+	userMsg string
 }
 
 func (err *e) Error() string {
@@ -96,30 +128,40 @@ func (err *e) Format(s fmt.State, verb rune) {
 //     that are useful in the context of logging, where contextual metadata from
 //     the error can eliminate large swathes of the DEBUG/Log driven debugging.
 func (err *e) Fields() []any {
+	return treeFields(err, err.stackTrace(), nil)
+}
+
+// treeFields returns the fields of every error in the error tree, followed
+// by the first Kind found and the stack frames. The ancestors are the errors
+// of the tree the err belongs to, that err is not to visit again.
+func treeFields(err error, stackFrames StackFrames, ancestors []error) []any {
 	var (
 		out  []any
 		kind Kind
+		path []error
 	)
-	for err := error(err); err != nil; err = Unwrap(err) {
-		em := getErrMeta(err)
+	walk(err, nil, ancestors, func(node error, depth int, _ []int) WalkAction {
+		path = append(path[:depth], node)
+		em := getErrMeta(node)
 		for _, kv := range em.kvs {
 			out = append(out, kv.K, kv.V)
 		}
 		kind = cmp.Or(kind, em.kind)
-		if ej, ok := err.(*joinE); ok {
-			innerKind, multiErrFields := ej.subErrFields()
+		if multi, ok := node.(interface{ Unwrap() []error }); ok {
+			innerKind, multiErrFields := subErrFields(multi.Unwrap(), slices.Concat(ancestors, path))
 			kind = cmp.Or(kind, innerKind)
 			if len(multiErrFields) > 0 {
 				out = append(out, "multi_err", multiErrFields)
 			}
-			break
+			return WalkSkip
 		}
-	}
+		return WalkContinue
+	})
 
 	if kind != "" {
 		out = append(out, "err_kind", string(kind))
 	}
-	if stackFrames := err.stackTrace(); len(stackFrames) > 0 {
+	if len(stackFrames) > 0 {
 		var simplified []string
 		for _, frame := range stackFrames {
 			simplified = append(simplified, frame.String())
@@ -132,7 +174,10 @@ func (err *e) Fields() []any {
 
 func (err *e) Is(target error) bool {
 	kind, ok := target.(Kind)
-	return ok && err.kind == kind
+	if !ok || kind == "" {
+		return false
+	}
+	return err.kind == kind || wrapsForeignKind(err, kind)
 }
 
 func (err *e) Unwrap() error {
@@ -140,28 +185,34 @@ func (err *e) Unwrap() error {
 }
 
 func (err *e) V(key string) (any, bool) {
-	for err := error(err); err != nil; err = Unwrap(err) {
-		for _, kv := range getErrMeta(err).kvs {
-			if kv.K == key {
-				return kv.V, true
-			}
-		}
-	}
-	return nil, false
+	return lookupV(err, key)
+}
+
+// StackTrace returns the stack frames of the error in the order of github.com/pkg/errors'
+// StackTrace, with the origin of the error first. Note, the error does not satisfy
+// the pkg/errors stackTracer interface, as the StackFrames type differs from the
+// pkg/errors StackTrace type. Only tooling that probes for the method by way of
+// reflection, (i.e. sentry reading the PC of each Frame), makes use of the frames.
+func (err *e) StackTrace() StackFrames {
+	return pkgErrorsOrder(err.stackTrace())
 }
 
+// stackTrace returns the frames of the error and the errors it wraps. The
+// frames of joined errors are not included, a joined error only provides
+// its own frame.
 func (err *e) stackTrace() StackFrames {
 	var out StackFrames
-	for err := error(err); err != nil; err = Unwrap(err) {
-		em := getErrMeta(err)
-		if em.frame.FilePath == "" {
-			continue
+	Walk(err, func(node error, _ int, _ []int) WalkAction {
+		em := getErrMeta(node)
+		if em.frame.FilePath != "" {
+			out = append(out, em.frame)
 		}
-		out = append(out, em.frame)
-		if em.errType == errTypeJoin {
-			break
+		out = append(out, em.frames...)
+		if _, ok := node.(interface{ Unwrap() []error }); ok {
+			return WalkSkip
 		}
-	}
+		return WalkContinue
+	})
 	return out
 }
 
@@ -169,30 +220,133 @@ type errMeta struct {
 	kind    Kind
 	frame   Frame
 	kvs     []KV
-	errType string
-}
+	hints   []Remediation
+	userMsg string
 
-const (
-	errTypeE    = "e"
-	errTypeJoin = "j"
-)
+	// stdKVs are the KVs extracted from the std lib error types, see
+	// stdErrKVs. These are included in kvs as well.
+	stdKVs []KV
+
+	// frames are the frames of an error implementing StackTracer in LIFO
+	// order. The errors of this pkg provide a single frame instead.
+	frames StackFrames
+}
 
 func getErrMeta(err error) errMeta {
 	var em errMeta
 	switch err := err.(type) {
 	case *e:
-		em.kind, em.frame, em.kvs, em.errType = err.kind, err.frame, err.kvs, errTypeE
+		em.kind, em.frame, em.kvs, em.hints = err.kind, err.frame, err.kvs, err.hints
+		em.userMsg = err.userMsg
 	case *joinE:
-		em.kind, em.frame, em.kvs, em.errType = err.kind, err.frame, err.kvs, errTypeJoin
+		em.kind, em.frame, em.kvs, em.hints = err.kind, err.frame, err.kvs, err.hints
+		em.userMsg = err.userMsg
+	case chain:
+		// the chain's errors are visited individually
+	default:
+		if kinder, ok := err.(Kinder); ok {
+			em.kind = kinder.Kind()
+		}
+		if fielder, ok := err.(Fielder); ok {
+			em.kvs = KVs(fielder.Fields()...)
+		}
+		em.stdKVs = stdErrKVs(err)
+		em.kvs = append(em.kvs, em.stdKVs...)
+		if st, ok := err.(StackTracer); ok {
+			em.frames = pkgErrorsOrder(st.StackTrace())
+		}
 	}
 	return em
 }
 
+// wrapsForeignKind determines if the foreign errors wrapped by err implement
+// Kinder with the kind. The std lib errors.Is has no way of matching a Kinder
+// on its own, so the errors of this pkg match the foreign errors they wrap on
+// their behalf. The search ends at the next error of this pkg, which matches
+// the foreign errors it wraps itself.
+func wrapsForeignKind(err error, kind Kind) bool {
+	var found bool
+	for _, child := range children(err) {
+		Walk(child, func(node error, _ int, _ []int) WalkAction {
+			switch node.(type) {
+			case *e, *joinE, chain:
+				return WalkSkip
+			}
+			if kinder, ok := node.(Kinder); ok && kinder.Kind() == kind {
+				found = true
+				return WalkStop
+			}
+			return WalkContinue
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// getKind returns the first Kind found in the error tree. See Walk for
+// the order the errors are visited in.
 func getKind(err error) Kind {
-	for ; err != nil; err = Unwrap(err) {
-		if em := getErrMeta(err); em.kind != "" {
-			return em.kind
+	var kind Kind
+	Walk(err, func(node error, _ int, _ []int) WalkAction {
+		kind = getErrMeta(node).kind
+		if kind != "" {
+			return WalkStop
 		}
+		return WalkContinue
+	})
+	return kind
+}
+
+// hasKind determines if any error in the error tree is of the Kind.
+func hasKind(err error, kind Kind) bool {
+	if kind == "" {
+		return false
 	}
-	return ""
+	var found bool
+	Walk(err, func(node error, _ int, _ []int) WalkAction {
+		found = getErrMeta(node).kind == kind
+		if found {
+			return WalkStop
+		}
+		return WalkContinue
+	})
+	return found
+}
+
+// lookupV returns the value of the first KV matching the key in the error
+// tree. See Walk for the order the errors are visited in.
+func lookupV(err error, key string) (any, bool) {
+	var (
+		v     any
+		found bool
+	)
+	Walk(err, func(node error, _ int, _ []int) WalkAction {
+		switch node.(type) {
+		case *e, *joinE, chain:
+		default:
+			if valuer, ok := node.(Valuer); ok {
+				if v, found = valuer.V(key); found {
+					return WalkStop
+				}
+			}
+		}
+		for _, kv := range getErrMeta(node).kvs {
+			if kv.K == key {
+				v, found = kv.V, true
+				return WalkStop
+			}
+		}
+		return WalkContinue
+	})
+	return v, found
+}
+
+// pkgErrorsOrder reverses the LIFO ordered stack frames, to match the
+// order of github.com/pkg/errors' StackTrace.
+func pkgErrorsOrder(frames StackFrames) StackFrames {
+	out := slices.Clone(frames)
+	slices.Reverse(out)
+	return out
 }
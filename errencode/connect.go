@@ -0,0 +1,122 @@
+package errencode
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+// The error codes of Connect and Twirp, indexed by their equivalent gRPC
+// status code.
+var codes = [...]string{
+	0:  "",
+	1:  "canceled",
+	2:  "unknown",
+	3:  "invalid_argument",
+	4:  "deadline_exceeded",
+	5:  "not_found",
+	6:  "already_exists",
+	7:  "permission_denied",
+	8:  "resource_exhausted",
+	9:  "failed_precondition",
+	10: "aborted",
+	11: "out_of_range",
+	12: "unimplemented",
+	13: "internal",
+	14: "unavailable",
+	15: "data_loss",
+	16: "unauthenticated",
+}
+
+// Code returns the Connect/Twirp error code of the error. The code is
+// determined by the Kind of the error, errors without a canonical Kind
+// have the code "unknown". A nil error has an empty code.
+func Code(err error) string {
+	if err == nil {
+		return ""
+	}
+	return codes[errkind.GRPCCode(err)]
+}
+
+// codeKind returns the canonical Kind of the Connect/Twirp error code.
+func codeKind(code string) errors.Kind {
+	kind := errors.Kind(code)
+	if _, ok := errkind.Lookup(kind); ok {
+		return kind
+	}
+	return ""
+}
+
+// Body is the JSON error body of Connect/Twirp style RPC protocols.
+//
+//	{"code":"not_found","msg":"user not found","meta":{"user_id":"1"}}
+type Body struct {
+	Code string            `json:"code"`
+	Msg  string            `json:"msg"`
+	Meta map[string]string `json:"meta,omitempty"`
+
+	Hints []errors.Remediation `json:"hints,omitempty"`
+}
+
+// ToBody converts the error to a Body. The msg is the public message of the
+// error, see errors.PublicMessage. The meta is populated from the KVs of the
+// error with values that are safe to convert to a string, and the hints from
+// the remediations of the error.
+func ToBody(err error) Body {
+	if err == nil {
+		return Body{}
+	}
+	return Body{
+		Code:  Code(err),
+		Msg:   errors.PublicMessage(err),
+		Meta:  SafeKVs(err),
+		Hints: errors.Hints(err),
+	}
+}
+
+// FromBody converts the Body to an error. The Kind of the error is the
+// canonical Kind of the code, and the meta and hints are provided as KVs
+// and remediations. The msg of the Body, being a public message, is restored
+// as the UserMessage of the error as well, see errors.PublicMessage. A Body
+// without a code returns a nil error.
+func FromBody(b Body) error {
+	if b.Code == "" {
+		return nil
+	}
+
+	opts := []any{errors.SkipCaller, errors.UserMessage(b.Msg)}
+	if kind := codeKind(b.Code); kind != "" {
+		opts = append(opts, kind)
+	}
+
+	keys := make([]string, 0, len(b.Meta))
+	for k := range b.Meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		opts = append(opts, errors.KV{K: k, V: b.Meta[k]})
+	}
+	for _, h := range b.Hints {
+		opts = append(opts, h)
+	}
+	return errors.New(b.Msg, opts...)
+}
+
+// EncodeBody writes the error to w as a JSON Body.
+func EncodeBody(w io.Writer, err error) error {
+	return json.NewEncoder(w).Encode(ToBody(err))
+}
+
+// DecodeBody reads a JSON Body from r. See FromBody for converting the
+// Body to an error.
+func DecodeBody(r io.Reader) (Body, error) {
+	var b Body
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return Body{}, errors.Wrap(err, "failed to decode error body")
+	}
+	return b, nil
+}
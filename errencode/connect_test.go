@@ -0,0 +1,166 @@
+package errencode_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errencode"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+type stringer string
+
+func (s stringer) String() string { return "stringer_" + string(s) }
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input error
+		want  string
+	}{
+		{name: "nil error", input: nil, want: ""},
+		{name: "canonical kind", input: errors.New("missing", errkind.NotFound), want: "not_found"},
+		{name: "timeout", input: errors.Wrap(context.DeadlineExceeded, errors.Classify), want: "deadline_exceeded"},
+		{name: "canceled", input: context.Canceled, want: "canceled"},
+		{name: "custom kind", input: errors.New("custom", errors.Kind("custom")), want: "unknown"},
+		{name: "foreign error", input: fmt.Errorf("foreign"), want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eq(t, tt.want, errencode.Code(tt.input))
+		})
+	}
+}
+
+func TestBody(t *testing.T) {
+	t.Run("encode", func(t *testing.T) {
+		err := errors.Wrap(
+			errors.New("user not found", errkind.NotFound, errors.KVs("user_id", 1, "admin", false)),
+			"get user",
+			errors.KVs("op", stringer("get"), "unsafe", struct{ secret string }{"shh"}),
+			errors.UserMessage("user not found"),
+		)
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errencode.EncodeBody(&buf, err)))
+
+		want := `{"code":"not_found","msg":"user not found","meta":{"admin":"false","op":"stringer_get","user_id":"1"}}` + "\n"
+		eq(t, want, buf.String())
+	})
+
+	t.Run("internal message is not leaked", func(t *testing.T) {
+		err := errors.Wrap(errors.New("pg: connection refused"), errors.UserMessage("try later"))
+		eq(t, "try later", errencode.ToBody(err).Msg)
+
+		err = errors.Wrap(errors.New("pg: connection refused"), errkind.Unavailable)
+		eq(t, "service unavailable", errencode.ToBody(err).Msg)
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		body, err := errencode.DecodeBody(strings.NewReader(`{"code":"permission_denied","msg":"denied","meta":{"user_id":"1"}}`))
+		must(t, eq(t, nil, err))
+
+		decoded := errencode.FromBody(body)
+		eq(t, "denied", decoded.Error())
+		eq(t, true, errors.Is(decoded, errkind.PermissionDenied))
+		eq(t, any("1"), errors.V(decoded, "user_id"))
+	})
+
+	t.Run("hints round trip", func(t *testing.T) {
+		err := errors.New("quota exceeded", errkind.ResourceExhausted, errors.Hint("retry later"), errors.HelpURL("https://example.com/quotas"))
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errencode.EncodeBody(&buf, err)))
+
+		want := `{"code":"resource_exhausted","msg":"resource exhausted","hints":[{"hint":"retry later"},{"help_url":"https://example.com/quotas"}]}` + "\n"
+		eq(t, want, buf.String())
+
+		body, decodeErr := errencode.DecodeBody(&buf)
+		must(t, eq(t, nil, decodeErr))
+
+		hints := errors.Hints(errencode.FromBody(body))
+		must(t, eq(t, 2, len(hints)))
+		eq(t, errors.Hint("retry later"), hints[0])
+		eq(t, errors.HelpURL("https://example.com/quotas"), hints[1])
+	})
+
+	t.Run("decode invalid body", func(t *testing.T) {
+		_, err := errencode.DecodeBody(strings.NewReader(`{`))
+		eq(t, true, err != nil)
+	})
+
+	t.Run("unknown codes have no kind", func(t *testing.T) {
+		decoded := errencode.FromBody(errencode.Body{Code: "data_loss", Msg: "lost"})
+		eq(t, "lost", decoded.Error())
+		eq(t, 0, len(errors.Kinds(decoded)))
+	})
+
+	t.Run("nil error round trips", func(t *testing.T) {
+		eq(t, nil, errencode.FromBody(errencode.ToBody(nil)))
+	})
+
+	t.Run("public message round trips", func(t *testing.T) {
+		err := errors.New("pg: no rows", errkind.NotFound, errors.UserMessage("user not found"))
+
+		decoded := errencode.FromBody(errencode.ToBody(err))
+		eq(t, "user not found", errors.PublicMessage(decoded))
+		eq(t, "user not found", errencode.ToBody(decoded).Msg)
+	})
+}
+
+func eq[T comparable](t *testing.T, want, got T) bool {
+	t.Helper()
+
+	matches := want == got
+	if !matches {
+		t.Errorf("values do not match:\n\t\twant:\t%#v\n\t\tgot:\t%#v", want, got)
+	}
+	return matches
+}
+
+func must(t *testing.T, outcome bool) {
+	t.Helper()
+
+	if !outcome {
+		t.FailNow()
+	}
+}
+
+func TestStdFieldsAreNotEncoded(t *testing.T) {
+	err := errors.Join(
+		errors.Wrap(
+			&fs.PathError{Op: "open", Path: "/srv/secrets/db-password.txt", Err: fs.ErrNotExist},
+			errors.UserMessage("could not start"),
+		),
+		errors.Wrap(
+			&net.OpError{Op: "dial", Net: "tcp", Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5432}, Err: os.NewSyscallError("connect", fs.ErrPermission)},
+			errors.KVs("dependency", "db"),
+		),
+	)
+
+	var buf bytes.Buffer
+	must(t, eq(t, nil, errencode.EncodeBody(&buf, err)))
+	for _, leaked := range []string{"db-password", "10.0.0.1", "5432", "connect", "open", "dial", "tcp"} {
+		eq(t, false, strings.Contains(buf.String(), leaked))
+	}
+	eq(t, "db", errencode.ToBody(err).Meta["dependency"])
+
+	rpc, marshalErr := json.Marshal(errencode.ToJSONRPC(err))
+	must(t, eq(t, nil, marshalErr))
+	eq(t, false, strings.Contains(string(rpc), "db-password"))
+	eq(t, false, strings.Contains(string(rpc), "10.0.0.1"))
+
+	gql, marshalErr := json.Marshal(errencode.ToGraphQL(err))
+	must(t, eq(t, nil, marshalErr))
+	eq(t, false, strings.Contains(string(gql), "db-password"))
+	eq(t, false, strings.Contains(string(gql), "10.0.0.1"))
+}
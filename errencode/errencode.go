@@ -0,0 +1,64 @@
+// Package errencode encodes the errors of github.com/jsteenb2/errors into the
+// error shapes of common wire protocols, and decodes them back. The Kind of an
+// error determines the protocol's error code, see the errkind pkg for the
+// canonical kinds. The message encoded is the public message of the error,
+// see errors.PublicMessage, so that the details of the implementation are
+// not leaked to clients. Only the KVs with values that are safe to convert
+// to a string, (i.e. strings, numbers, bools and fmt.Stringers), are encoded.
+// The details extracted from the std lib error types, (i.e. the path of an
+// *fs.PathError), are not encoded either, see errors.Layer. Everything else,
+// including the message of the error and the stack trace, remains with the
+// error for logging.
+package errencode
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jsteenb2/errors"
+)
+
+// SafeKVs returns the KVs of the error tree, with values that are safe to
+// convert to a string. The KVs extracted from the std lib error types are
+// left out. When a key is repeated, the first value found is used,
+// the same as errors.V. This is the policy of every encoder of the pkg,
+// and is exported so that other transports can redact the same way. A
+// nil error, or one without any safe KVs, returns nil.
+func SafeKVs(err error) map[string]string {
+	out := make(map[string]string)
+	addSafeKVs(out, errors.Inspect(err))
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func addSafeKVs(out map[string]string, layers []errors.Layer) {
+	for _, layer := range layers {
+		for _, kv := range layer.KVs {
+			if _, ok := out[kv.K]; ok {
+				continue
+			}
+			if v, ok := safeString(kv.V); ok {
+				out[kv.K] = v
+			}
+		}
+		for _, child := range layer.Children {
+			addSafeKVs(out, child)
+		}
+	}
+}
+
+func safeString(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), true
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	return "", false
+}
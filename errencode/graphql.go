@@ -0,0 +1,142 @@
+package errencode
+
+import (
+	stderrors "errors"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+// GraphQLError is an entry of the errors of a GraphQL response.
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// ToGraphQL converts the error to the entries of the errors of a GraphQL
+// response, with the path of the field that failed. A joined error provides
+// an entry for each of the errors it joins, with nested joins flattened. The
+// extensions of each entry carry the kind, the code, the fields and the
+// hints of the error. The code is the upper cased Connect/Twirp code, (i.e. NOT_FOUND),
+// following the GraphQL conventions. The message is the public message of
+// the error, see errors.PublicMessage. The context of the errors wrapping a
+// join is merged into each of its entries. The kind, fields and user message
+// of the joined error take precedence, followed by those of its nearest
+// wrapper. A nil error returns nil.
+func ToGraphQL(err error, path ...any) []GraphQLError {
+	var out []GraphQLError
+	for _, leaf := range joinLeaves(err, nil) {
+		out = append(out, toGraphQLError(leaf, path))
+	}
+	return out
+}
+
+func toGraphQLError(leaf joinLeaf, path []any) GraphQLError {
+	ext := make(map[string]any)
+	for k, v := range SafeKVs(leaf.err) {
+		ext[k] = v
+	}
+	kind, code := errors.KindOf(leaf.err), Code(leaf.err)
+	hints := errors.Hints(leaf.err)
+	var userMsg string
+	for _, layer := range errors.Inspect(leaf.err) {
+		if layer.UserMsg != "" {
+			userMsg = layer.UserMsg
+			break
+		}
+	}
+
+	for i := len(leaf.wrappers) - 1; i >= 0; i-- {
+		layer := errors.Inspect(leaf.wrappers[i])[0]
+		for _, kv := range layer.KVs {
+			if _, ok := ext[kv.K]; ok {
+				continue
+			}
+			if v, ok := safeString(kv.V); ok {
+				ext[kv.K] = v
+			}
+		}
+		if kind == "" {
+			kind = layer.Kind
+		}
+		if userMsg == "" {
+			userMsg = layer.UserMsg
+		}
+		if m, ok := errkind.Lookup(layer.Kind); ok && code == "unknown" {
+			code = codes[m.GRPCCode]
+		}
+		for _, h := range layer.Hints {
+			if !slices.Contains(hints, h) {
+				hints = append(hints, h)
+			}
+		}
+	}
+
+	if kind != "" {
+		ext["kind"] = string(kind)
+	}
+	ext["code"] = strings.ToUpper(code)
+	if len(hints) > 0 {
+		ext["hints"] = hints
+	}
+
+	if userMsg == "" {
+		// the public message of the code's kind, as the kind may be
+		// provided by a wrapper of the join
+		userMsg = errors.KindMessage(codeKind(code))
+	}
+
+	return GraphQLError{
+		Message:    userMsg,
+		Path:       path,
+		Extensions: ext,
+	}
+}
+
+// joinLeaf is an error joined by a join, along with the errors wrapping
+// the join, from the outermost to the join itself.
+type joinLeaf struct {
+	err      error
+	wrappers []error
+}
+
+// joinLeaves returns the errors joined by the first join found in the error,
+// with nested joins flattened. An error without a join is its own leaf. The
+// wrappers are the ancestors of the error, errors that are amongst them are
+// not visited again, protecting against cycles in the tree.
+func joinLeaves(err error, wrappers []error) []joinLeaf {
+	if err == nil || isAncestor(err, wrappers) {
+		return nil
+	}
+
+	// the full slice expression forces a copy, so that the wrappers
+	// of each leaf are not overwritten by its siblings
+	joinWrappers := wrappers[:len(wrappers):len(wrappers)]
+	for cur := err; cur != nil && !isAncestor(cur, joinWrappers); cur = stderrors.Unwrap(cur) {
+		joinWrappers = append(joinWrappers, cur)
+		multi, ok := cur.(interface{ Unwrap() []error })
+		if !ok {
+			continue
+		}
+
+		var out []joinLeaf
+		for _, joined := range multi.Unwrap() {
+			out = append(out, joinLeaves(joined, joinWrappers)...)
+		}
+		return out
+	}
+	return []joinLeaf{{err: err, wrappers: wrappers}}
+}
+
+// isAncestor reports whether the err is one of the ancestors. Errors of
+// types that are not comparable are never ancestors.
+func isAncestor(err error, ancestors []error) bool {
+	if !reflect.TypeOf(err).Comparable() {
+		return false
+	}
+	return slices.Contains(ancestors, err)
+}
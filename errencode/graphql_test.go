@@ -0,0 +1,113 @@
+package errencode_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errencode"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+func TestToGraphQL(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		eq(t, 0, len(errencode.ToGraphQL(nil)))
+	})
+
+	t.Run("single error", func(t *testing.T) {
+		err := errors.Wrap(errors.New("user not found", errkind.NotFound, errors.KVs("user_id", 1)), "resolve user", errors.UserMessage("user not found"))
+
+		got, marshalErr := json.Marshal(errencode.ToGraphQL(err, "user", 0))
+		must(t, eq(t, nil, marshalErr))
+
+		want := `[{"message":"user not found","path":["user",0],"extensions":{"code":"NOT_FOUND","kind":"not_found","user_id":"1"}}]`
+		eq(t, want, string(got))
+	})
+
+	t.Run("joined errors have an entry each", func(t *testing.T) {
+		err := errors.Wrap(errors.Join(
+			errors.New("bad name", errkind.InvalidArgument, errors.KVs("field", "name"), errors.UserMessage("name is invalid")),
+			errors.Join(
+				errors.New("bad email", errkind.InvalidArgument, errors.KVs("field", "email")),
+				fmt.Errorf("foreign"),
+			),
+		))
+
+		got := errencode.ToGraphQL(err)
+		must(t, eq(t, 3, len(got)))
+
+		eq(t, "name is invalid", got[0].Message)
+		eq(t, any("INVALID_ARGUMENT"), got[0].Extensions["code"])
+		eq(t, any("name"), got[0].Extensions["field"])
+
+		eq(t, "invalid argument", got[1].Message)
+		eq(t, any("email"), got[1].Extensions["field"])
+
+		eq(t, "internal error", got[2].Message)
+		eq(t, any("UNKNOWN"), got[2].Extensions["code"])
+		eq(t, nil, got[2].Extensions["kind"])
+	})
+
+	t.Run("joined errors include the context of the errors wrapping the join", func(t *testing.T) {
+		err := errors.Wrap(
+			errors.Join(
+				errors.New("first", errors.KVs("id", 1)),
+				errors.Wrap(errors.Join(
+					errors.New("second", errkind.InvalidArgument, errors.KVs("batch", "2"), errors.UserMessage("second is invalid")),
+				), errors.KVs("nested", true)),
+			),
+			errkind.NotFound, errors.KVs("batch", "1"), errors.Hint("check the batch"),
+		)
+
+		got, marshalErr := json.Marshal(errencode.ToGraphQL(err))
+		must(t, eq(t, nil, marshalErr))
+
+		want := `[` +
+			`{"message":"not found","extensions":{"batch":"1","code":"NOT_FOUND","hints":[{"hint":"check the batch"}],"id":"1","kind":"not_found"}},` +
+			`{"message":"second is invalid","extensions":{"batch":"2","code":"INVALID_ARGUMENT","hints":[{"hint":"check the batch"}],"kind":"invalid_argument","nested":"true"}}` +
+			`]`
+		eq(t, want, string(got))
+	})
+
+	t.Run("joined errors use the user message of the errors wrapping the join", func(t *testing.T) {
+		err := errors.Wrap(
+			errors.Join(errors.New("pg: connection refused"), errors.New("second", errors.UserMessage("second failed"))),
+			errors.UserMessage("batch failed"),
+		)
+
+		got := errencode.ToGraphQL(err)
+		must(t, eq(t, 2, len(got)))
+		eq(t, "batch failed", got[0].Message)
+		eq(t, "second failed", got[1].Message)
+	})
+
+	t.Run("hints are included", func(t *testing.T) {
+		err := errors.New("not logged in", errkind.Unauthenticated, errors.Hint("log in first"))
+
+		got, marshalErr := json.Marshal(errencode.ToGraphQL(err))
+		must(t, eq(t, nil, marshalErr))
+
+		want := `[{"message":"unauthenticated","extensions":{"code":"UNAUTHENTICATED","hints":[{"hint":"log in first"}],"kind":"unauthenticated"}}]`
+		eq(t, want, string(got))
+	})
+
+	t.Run("cyclic error trees are visited once", func(t *testing.T) {
+		cyclic := &cyclicErr{msg: "cycle"}
+		cyclic.next = errors.Join(cyclic, errors.New("other", errkind.NotFound, errors.NoFrame), errors.NoFrame)
+
+		got := errencode.ToGraphQL(cyclic)
+		must(t, eq(t, 1, len(got)))
+		eq(t, "not found", got[0].Message)
+		eq(t, "NOT_FOUND", got[0].Extensions["code"].(string))
+	})
+}
+
+// cyclicErr is a foreign error whose Unwrap chain may lead back to itself.
+type cyclicErr struct {
+	msg  string
+	next error
+}
+
+func (c *cyclicErr) Error() string { return c.msg }
+func (c *cyclicErr) Unwrap() error { return c.next }
@@ -0,0 +1,71 @@
+package errencode
+
+import (
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+// The error codes reserved by the JSON-RPC 2.0 spec.
+const (
+	JSONRPCInvalidParams  = -32602
+	JSONRPCMethodNotFound = -32601
+	JSONRPCInternalError  = -32603
+
+	// JSONRPCServerError is the start of the range of codes reserved for
+	// server errors, -32000 to -32099.
+	JSONRPCServerError = -32000
+)
+
+// JSONRPCError is the JSON-RPC 2.0 error object.
+type JSONRPCError struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Data    *JSONRPCData `json:"data,omitempty"`
+}
+
+// JSONRPCData is the additional information of a JSON-RPC 2.0 error object.
+type JSONRPCData struct {
+	Kind   string            `json:"kind,omitempty"`
+	Code   string            `json:"code,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+
+	Hints []errors.Remediation `json:"hints,omitempty"`
+}
+
+// ToJSONRPC converts the error to a JSON-RPC 2.0 error object. The kinds
+// with an equivalent in the spec, (i.e. invalid_argument for invalid params),
+// use the spec's code. The remaining canonical kinds are provided a code in
+// the range reserved for server errors, that is -32000 minus the gRPC code of
+// the kind. Errors without a canonical kind are internal errors. The message
+// is the public message of the error, see errors.PublicMessage. The data
+// carries the kind, the Connect/Twirp code, the fields and the hints of the
+// error. A nil error returns nil.
+func ToJSONRPC(err error) *JSONRPCError {
+	if err == nil {
+		return nil
+	}
+
+	return &JSONRPCError{
+		Code:    jsonRPCCode(err),
+		Message: errors.PublicMessage(err),
+		Data: &JSONRPCData{
+			Kind:   string(errors.KindOf(err)),
+			Code:   Code(err),
+			Fields: SafeKVs(err),
+			Hints:  errors.Hints(err),
+		},
+	}
+}
+
+func jsonRPCCode(err error) int {
+	switch grpcCode := errkind.GRPCCode(err); codes[grpcCode] {
+	case "invalid_argument":
+		return JSONRPCInvalidParams
+	case "unimplemented":
+		return JSONRPCMethodNotFound
+	case "internal", "unknown":
+		return JSONRPCInternalError
+	default:
+		return JSONRPCServerError - int(grpcCode)
+	}
+}
@@ -0,0 +1,68 @@
+package errencode_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errencode"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+func TestToJSONRPC(t *testing.T) {
+	tests := []struct {
+		name  string
+		input error
+		want  string
+	}{
+		{
+			name:  "nil error",
+			input: nil,
+			want:  `null`,
+		},
+		{
+			name:  "invalid argument is invalid params",
+			input: errors.New("bad email", errkind.InvalidArgument, errors.KVs("field", "email"), errors.UserMessage("email is invalid")),
+			want:  `{"code":-32602,"message":"email is invalid","data":{"kind":"invalid_argument","code":"invalid_argument","fields":{"field":"email"}}}`,
+		},
+		{
+			name:  "unimplemented is method not found",
+			input: errors.New("nope", errkind.Unimplemented),
+			want:  `{"code":-32601,"message":"not implemented","data":{"kind":"unimplemented","code":"unimplemented"}}`,
+		},
+		{
+			name:  "canonical kind is a server error",
+			input: errors.New("missing", errkind.NotFound),
+			want:  `{"code":-32005,"message":"not found","data":{"kind":"not_found","code":"not_found"}}`,
+		},
+		{
+			name:  "custom kind is an internal error",
+			input: errors.New("custom", errors.Kind("custom")),
+			want:  `{"code":-32603,"message":"internal error","data":{"kind":"custom","code":"unknown"}}`,
+		},
+		{
+			name:  "hints are included",
+			input: errors.New("quota exceeded", errkind.ResourceExhausted, errors.HelpURL("https://example.com/quotas")),
+			want:  `{"code":-32008,"message":"resource exhausted","data":{"kind":"resource_exhausted","code":"resource_exhausted","hints":[{"help_url":"https://example.com/quotas"}]}}`,
+		},
+		{
+			name:  "internal message is not leaked",
+			input: errors.Wrap(errors.New("pg: connection refused"), "failed to query users", errors.UserMessage("try later")),
+			want:  `{"code":-32603,"message":"try later","data":{"code":"unknown"}}`,
+		},
+		{
+			name:  "foreign error is an internal error",
+			input: fmt.Errorf("foreign"),
+			want:  `{"code":-32603,"message":"internal error","data":{"code":"unknown"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(errencode.ToJSONRPC(tt.input))
+			must(t, eq(t, nil, err))
+			eq(t, tt.want, string(got))
+		})
+	}
+}
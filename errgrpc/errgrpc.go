@@ -0,0 +1,176 @@
+// Package errgrpc converts the errors of github.com/jsteenb2/errors to and
+// from gRPC statuses. The Kind of an error determines the status code, while
+// the Kind and KVs are provided as the google.rpc.ErrorInfo details of the
+// status, and the hints as the google.rpc.LocalizedMessage and google.rpc.Help
+// details. This allows the Kind, KVs and hints of an error to cross the wire:
+//
+//	srv := grpc.NewServer(
+//		grpc.UnaryInterceptor(errgrpc.UnaryServerInterceptor()),
+//		grpc.StreamInterceptor(errgrpc.StreamServerInterceptor()),
+//	)
+//
+//	conn, err := grpc.NewClient(addr,
+//		grpc.WithUnaryInterceptor(errgrpc.UnaryClientInterceptor()),
+//		grpc.WithStreamInterceptor(errgrpc.StreamClientInterceptor()),
+//	)
+//
+// See the errkind pkg for the mapping of Kinds to status codes.
+package errgrpc
+
+import (
+	stderrors "errors"
+	"sort"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errencode"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+// Domain is the domain of the ErrorInfo details provided by ToStatus.
+const Domain = "github.com/jsteenb2/errors"
+
+// ToStatus converts the error to a gRPC status. The status code is determined
+// by the Kind of the error, see errkind.GRPCCode. The Kind and KVs of the error
+// are provided as ErrorInfo details, where the Kind is the reason and the KVs
+// are the metadata. Only the KVs that are safe to send over the wire are
+// provided, see errencode.SafeKVs. The hints of the error are provided as
+// LocalizedMessage details, and the help URLs as the links of a Help detail.
+// The message of the status is the public message of the error, see
+// errors.PublicMessage. An error that already carries a gRPC status, (i.e.
+// created by status.Error), and has no canonical Kind retains its status.
+// A nil error returns the OK status.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	code := codes.Code(errkind.GRPCCode(err))
+	if st, ok := grpcStatus(err); ok && code == codes.Unknown {
+		return st
+	}
+
+	var details []protoadapt.MessageV1
+	info := &errdetails.ErrorInfo{
+		Reason:   string(errors.KindOf(err)),
+		Domain:   Domain,
+		Metadata: errencode.SafeKVs(err),
+	}
+	if info.Reason != "" || len(info.Metadata) > 0 {
+		details = append(details, info)
+	}
+
+	help := new(errdetails.Help)
+	for _, h := range errors.Hints(err) {
+		if h.Hint != "" {
+			details = append(details, &errdetails.LocalizedMessage{Message: h.Hint})
+		}
+		if h.HelpURL != "" {
+			help.Links = append(help.Links, &errdetails.Help_Link{Url: h.HelpURL})
+		}
+	}
+	if len(help.Links) > 0 {
+		details = append(details, help)
+	}
+
+	st := status.New(code, errors.PublicMessage(err))
+	if len(details) == 0 {
+		return st
+	}
+
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st
+	}
+	return withDetails
+}
+
+// FromStatus converts the gRPC status to an error. The Kind and KVs of the
+// error are restored from the ErrorInfo details provided by ToStatus. When
+// the status has no such details, the Kind is determined by the status code.
+// The message of a status provided by ToStatus is restored as the UserMessage
+// of the error, see errors.PublicMessage. The LocalizedMessage and Help details
+// are restored as the hints and help URLs of the error.
+// The status is retained by the error, so that status.FromError continues to
+// work with the error. A nil or OK status returns a nil error.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	opts := []any{errors.NoFrame}
+	kind := codeKinds[st.Code()]
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.GetDomain() != Domain {
+				continue
+			}
+			if detail.GetReason() != "" {
+				kind = errors.Kind(detail.GetReason())
+			}
+			// the message of a status provided by ToStatus is a public message
+			opts = append(opts, errors.UserMessage(st.Message()))
+			md := detail.GetMetadata()
+			keys := make([]string, 0, len(md))
+			for k := range md {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				opts = append(opts, errors.KV{K: k, V: md[k]})
+			}
+		case *errdetails.LocalizedMessage:
+			opts = append(opts, errors.Hint(detail.GetMessage()))
+		case *errdetails.Help:
+			for _, link := range detail.GetLinks() {
+				opts = append(opts, errors.HelpURL(link.GetUrl()))
+			}
+		}
+	}
+	if kind != "" {
+		opts = append(opts, kind)
+	}
+	return errors.Wrap(&statusErr{st: st}, opts...)
+}
+
+// statusErr retains the status of an error converted by FromStatus.
+type statusErr struct {
+	st *status.Status
+}
+
+func (s *statusErr) Error() string {
+	return s.st.Message()
+}
+
+func (s *statusErr) GRPCStatus() *status.Status {
+	return s.st
+}
+
+// codeKinds maps the status codes back to the canonical kinds.
+var codeKinds = map[codes.Code]errors.Kind{}
+
+func init() {
+	kinds := []errors.Kind{
+		errkind.InvalidArgument, errkind.NotFound, errkind.AlreadyExists, errkind.PermissionDenied,
+		errkind.Unauthenticated, errkind.ResourceExhausted, errkind.FailedPrecondition, errkind.Aborted,
+		errkind.Unavailable, errkind.DeadlineExceeded, errkind.Canceled, errkind.Internal,
+		errkind.Unimplemented,
+	}
+	for _, kind := range kinds {
+		m, _ := errkind.Lookup(kind)
+		codeKinds[codes.Code(m.GRPCCode)] = kind
+	}
+}
+
+func grpcStatus(err error) (*status.Status, bool) {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !stderrors.As(err, &grpcErr) {
+		return nil, false
+	}
+	return grpcErr.GRPCStatus(), true
+}
@@ -0,0 +1,163 @@
+package errgrpc_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errgrpc"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      error
+		wantCode   codes.Code
+		wantMsg    string
+		wantReason string
+		wantMD     map[string]string
+	}{
+		{
+			name:     "nil error",
+			input:    nil,
+			wantCode: codes.OK,
+		},
+		{
+			name:       "canonical kind with kvs",
+			input:      errors.Wrap(errors.New("user not found", errkind.NotFound, errors.KVs("user_id", 1)), "get user", errors.KVs("op", "get"), errors.UserMessage("user not found")),
+			wantCode:   codes.NotFound,
+			wantMsg:    "user not found",
+			wantReason: "not_found",
+			wantMD:     map[string]string{"user_id": "1", "op": "get"},
+		},
+		{
+			name:       "custom kind",
+			input:      errors.New("custom", errors.Kind("custom_kind")),
+			wantCode:   codes.Unknown,
+			wantMsg:    "internal error",
+			wantReason: "custom_kind",
+		},
+		{
+			name:       "classified std lib error",
+			input:      errors.Wrap(context.Canceled, "canceled", errors.NoFrame),
+			wantCode:   codes.Canceled,
+			wantMsg:    "canceled",
+			wantReason: "canceled",
+		},
+		{
+			name: "only safe kvs are provided",
+			input: errors.Wrap(
+				&fs.PathError{Op: "open", Path: "/srv/secrets/db-password.txt", Err: fs.ErrNotExist},
+				errors.KVs("user_id", 2, "req", struct{ Token string }{Token: "secret"}),
+			),
+			wantCode:   codes.NotFound,
+			wantMsg:    "not found",
+			wantReason: "not_found",
+			wantMD:     map[string]string{"user_id": "2"},
+		},
+		{
+			name:     "existing status is retained",
+			input:    errors.Wrap(status.Error(codes.DataLoss, "lost")),
+			wantCode: codes.DataLoss,
+			wantMsg:  "lost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := errgrpc.ToStatus(tt.input)
+
+			eq(t, tt.wantCode, st.Code())
+			eq(t, tt.wantMsg, st.Message())
+
+			info := errorInfo(st)
+			if tt.wantReason == "" && tt.wantMD == nil {
+				if info != nil {
+					t.Fatalf("unexpected error info: %v", info)
+				}
+				return
+			}
+			if info == nil {
+				t.Fatal("missing error info")
+			}
+			eq(t, errgrpc.Domain, info.GetDomain())
+			eq(t, tt.wantReason, info.GetReason())
+			eq(t, len(tt.wantMD), len(info.GetMetadata()))
+			for k, v := range tt.wantMD {
+				eq(t, v, info.GetMetadata()[k])
+			}
+		})
+	}
+}
+
+func TestFromStatus(t *testing.T) {
+	t.Run("nil and OK statuses are nil errors", func(t *testing.T) {
+		eq(t, nil, errgrpc.FromStatus(nil))
+		eq(t, nil, errgrpc.FromStatus(status.New(codes.OK, "")))
+	})
+
+	t.Run("round trip restores kind and kvs", func(t *testing.T) {
+		in := errors.New("pg: no rows", errors.Kind("user_not_found"), errors.KVs("user_id", 1), errors.UserMessage("user not found"))
+
+		err := errgrpc.FromStatus(errgrpc.ToStatus(in))
+
+		eq(t, "user not found", err.Error())
+		eq(t, "user not found", errors.PublicMessage(err))
+		eq(t, true, errors.Is(err, errors.Kind("user_not_found")))
+		eq(t, any("1"), errors.V(err, "user_id"))
+		eq(t, codes.Unknown, status.Code(err))
+	})
+
+	t.Run("round trip restores hints", func(t *testing.T) {
+		in := errors.New("quota exceeded", errkind.ResourceExhausted, errors.Hint("retry later"), errors.HelpURL("https://example.com/quotas"))
+
+		st := errgrpc.ToStatus(in)
+		must(t, eq(t, 3, len(st.Details())))
+
+		hints := errors.Hints(errgrpc.FromStatus(st))
+		must(t, eq(t, 2, len(hints)))
+		eq(t, errors.Hint("retry later"), hints[0])
+		eq(t, errors.HelpURL("https://example.com/quotas"), hints[1])
+	})
+
+	t.Run("without error info the kind is determined by the code", func(t *testing.T) {
+		err := errgrpc.FromStatus(status.New(codes.PermissionDenied, "denied"))
+
+		eq(t, "denied", err.Error())
+		eq(t, true, errors.Is(err, errkind.PermissionDenied))
+		eq(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func errorInfo(st *status.Status) *errdetails.ErrorInfo {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+func eq[T comparable](t *testing.T, want, got T) bool {
+	t.Helper()
+
+	matches := want == got
+	if !matches {
+		t.Errorf("values do not match:\n\t\twant:\t%#v\n\t\tgot:\t%#v", want, got)
+	}
+	return matches
+}
+
+func must(t *testing.T, outcome bool) {
+	t.Helper()
+
+	if !outcome {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,115 @@
+package errgrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errgrpc"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+type healthSvc struct {
+	healthpb.UnimplementedHealthServer
+	err error
+}
+
+func (h *healthSvc) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return nil, h.err
+}
+
+func (h *healthSvc) Watch(*healthpb.HealthCheckRequest, healthpb.Health_WatchServer) error {
+	return h.err
+}
+
+func TestInterceptors(t *testing.T) {
+	svcErr := errors.New("db is not ready", errkind.Unavailable, errors.KVs("dependency", "db"), errors.UserMessage("service not ready"))
+
+	t.Run("unary", func(t *testing.T) {
+		client := newHealthClient(t, svcErr, true)
+
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		assertConvertedErr(t, err)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		client := newHealthClient(t, svcErr, true)
+
+		stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error opening stream: %v", err)
+		}
+		_, err = stream.Recv()
+		assertConvertedErr(t, err)
+	})
+
+	t.Run("without client interceptor the status is provided", func(t *testing.T) {
+		client := newHealthClient(t, svcErr, false)
+
+		_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+		st := status.Convert(err)
+		eq(t, codes.Unavailable, st.Code())
+		eq(t, "service not ready", st.Message())
+		info := errorInfo(st)
+		if info == nil {
+			t.Fatal("missing error info")
+		}
+		eq(t, "unavailable", info.GetReason())
+		eq(t, "db", info.GetMetadata()["dependency"])
+	})
+}
+
+func assertConvertedErr(t *testing.T, err error) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	eq(t, "service not ready", err.Error())
+	eq(t, true, errors.Is(err, errkind.Unavailable))
+	eq(t, any("db"), errors.V(err, "dependency"))
+	eq(t, codes.Unavailable, status.Code(err))
+}
+
+func newHealthClient(t *testing.T, svcErr error, withClientInterceptors bool) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(errgrpc.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(errgrpc.StreamServerInterceptor()),
+	)
+	healthpb.RegisterHealthServer(srv, &healthSvc{err: svcErr})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	opts := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	if withClientInterceptors {
+		opts = append(opts,
+			grpc.WithUnaryInterceptor(errgrpc.UnaryClientInterceptor()),
+			grpc.WithStreamInterceptor(errgrpc.StreamClientInterceptor()),
+		)
+	}
+
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
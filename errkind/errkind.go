@@ -0,0 +1,164 @@
+// Package errkind provides a canonical set of errors.Kind, modeled on the
+// gRPC and Google API status codes. Using a shared set of kinds allows for
+// errors to be handled across team and service boundaries, without each
+// having to agree on the spelling of their own kinds:
+//
+//	return errors.New("user not found", errkind.NotFound)
+//
+//	// elsewhere
+//	if errors.Is(err, errkind.NotFound) {
+//		// handle not found
+//	}
+//
+// Each kind has a default HTTP status and gRPC code, see HTTPStatus and GRPCCode
+// for more info. The exit codes of the kinds are provided by errors.ExitCode.
+package errkind
+
+import (
+	"net/http"
+
+	"github.com/jsteenb2/errors"
+)
+
+const (
+	// InvalidArgument indicates the caller provided an invalid argument,
+	// regardless of the state of the system, (i.e. a malformed email).
+	InvalidArgument = errors.KindInvalidArgument
+
+	// NotFound indicates a requested entity was not found.
+	NotFound = errors.KindNotFound
+
+	// AlreadyExists indicates the entity a caller attempted to create
+	// already exists.
+	AlreadyExists = errors.KindAlreadyExists
+
+	// PermissionDenied indicates the caller does not have permission to
+	// perform the operation. Use Unauthenticated when the caller can't
+	// be identified instead.
+	PermissionDenied = errors.KindPermissionDenied
+
+	// Unauthenticated indicates the caller does not have valid credentials
+	// for the operation.
+	Unauthenticated = errors.KindUnauthenticated
+
+	// ResourceExhausted indicates a resource has been exhausted, (i.e. a
+	// rate limit or quota).
+	ResourceExhausted = errors.KindResourceExhausted
+
+	// FailedPrecondition indicates the system is not in the state required
+	// for the operation, (i.e. deleting a non-empty directory).
+	FailedPrecondition = errors.KindFailedPrecondition
+
+	// Aborted indicates the operation was aborted, typically due to a
+	// concurrency issue, (i.e. a transaction conflict).
+	Aborted = errors.KindAborted
+
+	// Unavailable indicates the service is currently unavailable. This is
+	// most likely a transient condition, that may be resolved by retrying.
+	Unavailable = errors.KindUnavailable
+
+	// DeadlineExceeded indicates the deadline expired before the operation
+	// could complete. This is the kind errors.Classify provides for timeouts.
+	DeadlineExceeded = errors.KindDeadlineExceeded
+
+	// Canceled indicates the operation was canceled, typically by the caller.
+	Canceled = errors.KindCanceled
+
+	// Internal indicates an invariant of the system has been broken. This
+	// is reserved for serious errors.
+	Internal = errors.KindInternal
+
+	// Unimplemented indicates the operation is not implemented or supported.
+	Unimplemented = errors.KindUnimplemented
+)
+
+// The gRPC status codes of the kinds. These match the values of the
+// google.golang.org/grpc/codes pkg, without requiring the dependency.
+const (
+	grpcCanceled           = 1
+	grpcUnknown            = 2
+	grpcInvalidArgument    = 3
+	grpcDeadlineExceeded   = 4
+	grpcNotFound           = 5
+	grpcAlreadyExists      = 6
+	grpcPermissionDenied   = 7
+	grpcResourceExhausted  = 8
+	grpcFailedPrecondition = 9
+	grpcAborted            = 10
+	grpcUnimplemented      = 12
+	grpcInternal           = 13
+	grpcUnavailable        = 14
+	grpcUnauthenticated    = 16
+)
+
+// Mapping is the default HTTP status and gRPC code of a kind.
+type Mapping struct {
+	HTTPStatus int
+	GRPCCode   uint32
+}
+
+var mappings = map[errors.Kind]Mapping{
+	InvalidArgument:    {HTTPStatus: http.StatusBadRequest, GRPCCode: grpcInvalidArgument},
+	NotFound:           {HTTPStatus: http.StatusNotFound, GRPCCode: grpcNotFound},
+	AlreadyExists:      {HTTPStatus: http.StatusConflict, GRPCCode: grpcAlreadyExists},
+	PermissionDenied:   {HTTPStatus: http.StatusForbidden, GRPCCode: grpcPermissionDenied},
+	Unauthenticated:    {HTTPStatus: http.StatusUnauthorized, GRPCCode: grpcUnauthenticated},
+	ResourceExhausted:  {HTTPStatus: http.StatusTooManyRequests, GRPCCode: grpcResourceExhausted},
+	FailedPrecondition: {HTTPStatus: http.StatusBadRequest, GRPCCode: grpcFailedPrecondition},
+	Aborted:            {HTTPStatus: http.StatusConflict, GRPCCode: grpcAborted},
+	Unavailable:        {HTTPStatus: http.StatusServiceUnavailable, GRPCCode: grpcUnavailable},
+	DeadlineExceeded:   {HTTPStatus: http.StatusGatewayTimeout, GRPCCode: grpcDeadlineExceeded},
+	Canceled:           {HTTPStatus: 499, GRPCCode: grpcCanceled},
+	Internal:           {HTTPStatus: http.StatusInternalServerError, GRPCCode: grpcInternal},
+	Unimplemented:      {HTTPStatus: http.StatusNotImplemented, GRPCCode: grpcUnimplemented},
+}
+
+// unknown is the mapping of errors without a canonical kind.
+var unknown = Mapping{
+	HTTPStatus: http.StatusInternalServerError,
+	GRPCCode:   grpcUnknown,
+}
+
+// Lookup returns the Mapping of the kind. When the kind is not one of the
+// canonical kinds, false is returned.
+func Lookup(kind errors.Kind) (Mapping, bool) {
+	m, ok := mappings[kind]
+	return m, ok
+}
+
+// Of returns the Mapping of the error. The first canonical kind found in the
+// error tree determines the mapping. When the error has no canonical kind, the
+// error is classified with errors.KindOf. Errors that can't be mapped are
+// provided the mapping of an unknown error, that is an HTTP status of 500 and
+// the gRPC code Unknown. A nil error is provided the mapping of success, that
+// is an HTTP status of 200 and the gRPC code OK.
+func Of(err error) Mapping {
+	if err == nil {
+		return Mapping{HTTPStatus: http.StatusOK}
+	}
+	for _, kind := range errors.Kinds(err) {
+		if m, ok := mappings[kind]; ok {
+			return m
+		}
+	}
+	if m, ok := mappings[errors.KindOf(err)]; ok {
+		return m
+	}
+	return unknown
+}
+
+// HTTPStatus returns the HTTP status code of the error. See Of for more info.
+func HTTPStatus(err error) int {
+	return Of(err).HTTPStatus
+}
+
+// GRPCCode returns the gRPC status code of the error. See Of for more info.
+func GRPCCode(err error) uint32 {
+	return Of(err).GRPCCode
+}
+
+// ExitCode returns the exit code of the error. This is the same as
+// errors.ExitCode, the exit codes are customized with errors.SetExitCode.
+func ExitCode(err error) int {
+	return errors.ExitCode(err)
+}
@@ -0,0 +1,113 @@
+package errkind_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+	"github.com/jsteenb2/errors/errkind"
+)
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    error
+		want     errkind.Mapping
+		wantExit int
+	}{
+		{
+			name:  "nil error",
+			input: nil,
+			want:  errkind.Mapping{HTTPStatus: 200},
+		},
+		{
+			name:     "canonical kind",
+			input:    errors.New("missing", errkind.NotFound),
+			want:     errkind.Mapping{HTTPStatus: 404, GRPCCode: 5},
+			wantExit: 66,
+		},
+		{
+			name:     "wrapped canonical kind",
+			input:    errors.Wrap(fmt.Errorf("ctx: %w", errors.New("rate limited", errkind.ResourceExhausted))),
+			want:     errkind.Mapping{HTTPStatus: 429, GRPCCode: 8},
+			wantExit: 75,
+		},
+		{
+			name:     "first canonical kind amongst custom kinds",
+			input:    errors.Wrap(errors.New("taken", errkind.AlreadyExists), errors.Kind("custom")),
+			want:     errkind.Mapping{HTTPStatus: 409, GRPCCode: 6},
+			wantExit: 73,
+		},
+		{
+			name:     "classified std lib error",
+			input:    context.DeadlineExceeded,
+			want:     errkind.Mapping{HTTPStatus: 504, GRPCCode: 4},
+			wantExit: 75,
+		},
+		{
+			name:     "unknown error",
+			input:    errors.New("unknown", errors.Kind("custom")),
+			want:     errkind.Mapping{HTTPStatus: 500, GRPCCode: 2},
+			wantExit: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errkind.Of(tt.input)
+			if got != tt.want {
+				t.Errorf("mappings do not match:\n\t\twant:\t%+v\n\t\tgot:\t%+v", tt.want, got)
+			}
+
+			if got := errkind.HTTPStatus(tt.input); got != tt.want.HTTPStatus {
+				t.Errorf("http status do not match:\n\t\twant:\t%d\n\t\tgot:\t%d", tt.want.HTTPStatus, got)
+			}
+			if got := errkind.GRPCCode(tt.input); got != tt.want.GRPCCode {
+				t.Errorf("grpc codes do not match:\n\t\twant:\t%d\n\t\tgot:\t%d", tt.want.GRPCCode, got)
+			}
+			if got := errkind.ExitCode(tt.input); got != tt.wantExit {
+				t.Errorf("exit codes do not match:\n\t\twant:\t%d\n\t\tgot:\t%d", tt.wantExit, got)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	kinds := []errors.Kind{
+		errkind.InvalidArgument, errkind.NotFound, errkind.AlreadyExists, errkind.PermissionDenied,
+		errkind.Unauthenticated, errkind.ResourceExhausted, errkind.FailedPrecondition, errkind.Aborted,
+		errkind.Unavailable, errkind.DeadlineExceeded, errkind.Canceled,
+		errkind.Internal, errkind.Unimplemented,
+	}
+	for _, kind := range kinds {
+		if _, ok := errkind.Lookup(kind); !ok {
+			t.Errorf("missing mapping for kind %q", kind)
+		}
+	}
+
+	if _, ok := errkind.Lookup("custom"); ok {
+		t.Error("unexpected mapping for custom kind")
+	}
+}
+
+func TestClassify_IsCanonical(t *testing.T) {
+	err := errors.Wrap(context.DeadlineExceeded, errors.Classify)
+	if !errors.Is(err, errkind.DeadlineExceeded) {
+		t.Errorf("expected classified deadline to be of kind %q", errkind.DeadlineExceeded)
+	}
+
+	err = errors.Wrap(context.Canceled, errors.Classify)
+	if !errors.Is(err, errkind.Canceled) {
+		t.Errorf("expected classified cancelation to be of kind %q", errkind.Canceled)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	kind := errors.Kind("errkind_test_custom")
+	t.Cleanup(errors.SetExitCode(kind, 42))
+
+	if got := errkind.ExitCode(errors.New("custom", kind)); got != 42 {
+		t.Errorf("exit codes do not match:\n\t\twant:\t%d\n\t\tgot:\t%d", 42, got)
+	}
+}
@@ -1,5 +1,10 @@
 package errors
 
+import (
+	"slices"
+	"strconv"
+)
+
 // New creates a new error.
 func New(msg string, opts ...any) error {
 	passedOpts := make([]any, 1, len(opts)+1)
@@ -39,43 +44,101 @@ func Disjoin(err error) []error {
 		return nil
 	}
 
-	if stdJoin, ok := err.(interface{ Unwrap() []error }); ok {
-		return stdJoin.Unwrap()
-	}
-
-	if ej, ok := err.(*joinE); ok {
-		return ej.errs
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		return multi.Unwrap()
 	}
 
 	return nil
 }
 
-// Fields returns logging fields for a given error.
+// Fields returns logging fields for a given error. Errors that are not
+// of this pkg, (i.e. fmt.Errorf with %w), have the fields of the errors
+// in their error tree returned, including those of errors implementing
+// Fielder, Kinder or StackTracer.
 func Fields(err error) []any {
-	if err == nil {
+	switch err := err.(type) {
+	case nil:
 		return nil
+	case *e, *joinE, chain:
+		return err.(Fielder).Fields()
 	}
-
-	fielder, ok := err.(interface{ Fields() []any })
-	if !ok {
-		return nil
-	}
-
-	return fielder.Fields()
+	return treeFields(err, StackTrace(err), nil)
 }
 
 // StackTrace returns the StackFrames for an error. See StackFrames for more info.
-// TODO:
-//  1. make this more robust with Is
-//  2. determine if its even worth exposing an accessor for this private method
-//  3. allow for StackTraces() to accommodate joined errors, perhaps returning a map[string]StackFrames
-//     or some graph representation would be awesome.
+// The error is unwrapped until an error with a stack trace is found. This
+// allows for an error of this pkg wrapped by another error, (i.e. fmt.Errorf
+// with %w) to still provide its stack trace. A joined error provides its own
+// frame, the stack traces of the errors it joins are not included. See
+// StackTraces for the stack traces of joined errors.
 func StackTrace(err error) StackFrames {
-	ee, ok := err.(interface{ stackTrace() StackFrames })
-	if !ok {
+	var out StackFrames
+	Walk(err, func(node error, _ int, _ []int) WalkAction {
+		if ee, ok := node.(interface{ stackTrace() StackFrames }); ok {
+			out = ee.stackTrace()
+			return WalkStop
+		}
+		if st, ok := node.(StackTracer); ok {
+			out = pkgErrorsOrder(st.StackTrace())
+			return WalkStop
+		}
+		if _, ok := node.(interface{ Unwrap() []error }); ok {
+			return WalkStop
+		}
+		return WalkContinue
+	})
+	return out
+}
+
+// StackTraces returns the StackFrames for every branch of the error tree,
+// keyed by the path of joins taken to reach the branch. The branch of the
+// root error is keyed by the empty string, while the branches of joined
+// errors are keyed by their index amongst their siblings, (i.e. err_1.err_0
+// is the first joined error of the second joined error of the root branch).
+// This is the same naming used by Fields. A branch includes the frames of
+// every error up to and including the join ending the branch. Branches
+// without any frames are omitted.
+//
+//	for path, frames := range errors.StackTraces(err) {
+//		log.Println(path, frames)
+//	}
+func StackTraces(err error) map[string]StackFrames {
+	if err == nil {
 		return nil
 	}
-	return ee.stackTrace()
+	out := make(map[string]StackFrames)
+	stackTraces(err, "", nil, out)
+	return out
+}
+
+func stackTraces(err error, key string, ancestors []error, out map[string]StackFrames) {
+	for err != nil && !isAncestor(err, ancestors) {
+		em := getErrMeta(err)
+		if em.frame.FilePath != "" {
+			out[key] = append(out[key], em.frame)
+		}
+		if len(em.frames) > 0 {
+			out[key] = append(out[key], em.frames...)
+		}
+		ancestors = append(ancestors, err)
+
+		if isMulti(err) {
+			for i, child := range children(err) {
+				childKey := "err_" + strconv.Itoa(i)
+				if key != "" {
+					childKey = key + "." + childKey
+				}
+				stackTraces(child, childKey, ancestors[:len(ancestors):len(ancestors)], out)
+			}
+			return
+		}
+
+		var next error
+		if kids := children(err); len(kids) > 0 {
+			next = kids[0]
+		}
+		err = next
+	}
 }
 
 // V returns a typed value for the kvs of an error. Type conversion
@@ -100,15 +163,52 @@ func StackTrace(err error) StackFrames {
 //     guidance for this via the comment above and perhaps some example
 //     code.
 func V(err error, key string) any {
-	if err == nil {
-		return nil
-	}
+	raw, _ := lookupV(err, key)
+	return raw
+}
 
-	fielder, ok := err.(interface{ V(key string) (any, bool) })
-	if !ok {
-		return nil
+// Kinds returns every distinct Kind in the error tree, in the order they
+// are found. See Walk for the order the errors are visited in. Unlike
+// errors.Is, which answers whether a single Kind is present, Kinds allows
+// for a joined error that contains errors of different kinds to be handled
+// as a whole.
+func Kinds(err error) []Kind {
+	var out []Kind
+	Walk(err, func(node error, _ int, _ []int) WalkAction {
+		if kind := getErrMeta(node).kind; kind != "" && !slices.Contains(out, kind) {
+			out = append(out, kind)
+		}
+		return WalkContinue
+	})
+	return out
+}
+
+// IsAny determines if any of the kinds is found in the error tree.
+//
+//	if errors.IsAny(err, ErrKindInvalid, ErrKindNotFound) {
+//		// handle client errors
+//	}
+func IsAny(err error, kinds ...Kind) bool {
+	errKinds := Kinds(err)
+	for _, kind := range kinds {
+		if slices.Contains(errKinds, kind) {
+			return true
+		}
 	}
+	return false
+}
 
-	raw, _ := fielder.V(key)
-	return raw
+// IsAll determines if all the kinds are found in the error tree. When no
+// kinds are provided, IsAll returns false.
+func IsAll(err error, kinds ...Kind) bool {
+	if len(kinds) == 0 {
+		return false
+	}
+	errKinds := Kinds(err)
+	for _, kind := range kinds {
+		if !slices.Contains(errKinds, kind) {
+			return false
+		}
+	}
+	return true
 }
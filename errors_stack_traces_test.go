@@ -263,3 +263,33 @@ func TestE_Format(t *testing.T) {
 		eq(t, want, fmt.Sprintf("%q", err))
 	})
 }
+
+func TestStackTraces(t *testing.T) {
+	t.Run("with nil error should return nil", func(t *testing.T) {
+		eq(t, 0, len(errors.StackTraces(nil)))
+	})
+
+	t.Run("with joins of joins each branch is keyed by its path", func(t *testing.T) {
+		err := errors.Wrap(
+			errors.Join(
+				errors.New("first"),
+				errors.Join(
+					sentinelErr,
+					errors.Wrap(errors.New("nested"), "wrapped"),
+				),
+				fmt.Errorf("no frames"),
+			),
+		)
+
+		got := errors.StackTraces(err)
+		eq(t, 4, len(got))
+
+		eq(t, "[ github.com/jsteenb2/errors/errors_stack_traces_test.go:273[TestStackTraces.func2], github.com/jsteenb2/errors/errors_stack_traces_test.go:274[TestStackTraces.func2] ]", got[""].String())
+		eq(t, "[ github.com/jsteenb2/errors/errors_stack_traces_test.go:275[TestStackTraces.func2] ]", got["err_0"].String())
+		eq(t, "[ github.com/jsteenb2/errors/errors_stack_traces_test.go:276[TestStackTraces.func2] ]", got["err_1"].String())
+		eq(t, "[ github.com/jsteenb2/errors/errors_stack_traces_test.go:278[TestStackTraces.func2], github.com/jsteenb2/errors/errors_stack_traces_test.go:278[TestStackTraces.func2] ]", got["err_1.err_1"].String())
+
+		_, ok := got["err_2"]
+		eq(t, false, ok)
+	})
+}
@@ -64,6 +64,39 @@ func TestV(t *testing.T) {
 	})
 }
 
+func TestKinds(t *testing.T) {
+	err := errors.Wrap(
+		errors.Join(
+			errors.New("invalid", errors.Kind("invalid")),
+			errors.New("internal", errors.Kind("internal")),
+			errors.Wrap(errors.New("invalid again", errors.Kind("invalid"))),
+		),
+		errors.Kind("batch"),
+	)
+
+	t.Run("every distinct kind is returned", func(t *testing.T) {
+		got := errors.Kinds(err)
+		must(t, eqLen(t, 3, got))
+		eq(t, "batch", got[0])
+		eq(t, "invalid", got[1])
+		eq(t, "internal", got[2])
+
+		eqLen(t, 0, errors.Kinds(nil))
+	})
+
+	t.Run("IsAny matches any of the kinds", func(t *testing.T) {
+		eq(t, true, errors.IsAny(err, "not_found", "internal"))
+		eq(t, false, errors.IsAny(err, "not_found", "conflict"))
+		eq(t, false, errors.IsAny(err))
+	})
+
+	t.Run("IsAll matches all the kinds", func(t *testing.T) {
+		eq(t, true, errors.IsAll(err, "invalid", "internal"))
+		eq(t, false, errors.IsAll(err, "invalid", "not_found"))
+		eq(t, false, errors.IsAll(err))
+	})
+}
+
 func eq[T comparable](t *testing.T, want, got T) bool {
 	t.Helper()
 
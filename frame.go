@@ -16,6 +16,11 @@ type Frame struct {
 	FilePath string
 	Fn       string
 	Line     int
+
+	// PC is the program counter of the frame, as returned by runtime.Callers.
+	// This allows for tooling that symbolizes the program counters itself,
+	// (i.e. sentry) to work with the frames.
+	PC uintptr
 }
 
 // String formats Frame to string.
@@ -104,15 +109,18 @@ func getFrame(skip FrameSkips) (Frame, bool) {
 		return Frame{}, false
 	}
 
-	pc, path, line, ok := runtime.Caller(int(skip))
-	if !ok {
+	// the additional skip accounts for runtime.Callers itself
+	var pcs [1]uintptr
+	if runtime.Callers(int(skip)+1, pcs[:]) == 0 {
 		return Frame{}, false
 	}
 
+	rf, _ := runtime.CallersFrames(pcs[:]).Next()
 	frame := Frame{
-		Fn:       runtime.FuncForPC(pc).Name(),
-		Line:     line,
-		FilePath: path,
+		Fn:       rf.Function,
+		Line:     rf.Line,
+		FilePath: rf.File,
+		PC:       pcs[0],
 	}
 
 	return frame, true
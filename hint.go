@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"slices"
+)
+
+// Remediation is actionable guidance for resolving an error, for the
+// operators and users that encounter it. Unlike KVs, which are diagnostic
+// data for logging, a Remediation is meant to be shown to the user. See
+// Hint and HelpURL.
+type Remediation struct {
+	Hint    string `json:"hint,omitempty"`
+	HelpURL string `json:"help_url,omitempty"`
+}
+
+// Hint provides a remediation hint to the error.
+//
+//	errors.New("not logged in", errors.Hint("run `foo login` first"))
+func Hint(text string) Remediation {
+	return Remediation{Hint: text}
+}
+
+// HelpURL provides a link to documentation that helps resolve the error.
+//
+//	errors.Wrap(err, errors.HelpURL("https://example.com/docs/quotas"))
+func HelpURL(url string) Remediation {
+	return Remediation{HelpURL: url}
+}
+
+// Hints returns the remediations of every error in the error tree, in the
+// order they are found. See Walk for the order the errors are visited in.
+// Duplicate remediations are only returned once.
+func Hints(err error) []Remediation {
+	var out []Remediation
+	Walk(err, func(node error, _ int, _ []int) WalkAction {
+		for _, h := range getErrMeta(node).hints {
+			if !slices.Contains(out, h) {
+				out = append(out, h)
+			}
+		}
+		return WalkContinue
+	})
+	return out
+}
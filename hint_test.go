@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestHints(t *testing.T) {
+	t.Run("with nil error should return nil", func(t *testing.T) {
+		eqLen(t, 0, errors.Hints(nil))
+	})
+
+	t.Run("hints are found across the error tree", func(t *testing.T) {
+		err := errors.Wrap(
+			errors.Join(
+				errors.New("not logged in", errors.Hint("run `foo login` first")),
+				fmt.Errorf("foreign: %w", errors.New("quota exceeded", errors.HelpURL("https://example.com/quotas"))),
+				errors.Hint("retry the batch"),
+			),
+			errors.Hint("run `foo login` first"),
+		)
+
+		got := errors.Hints(err)
+		must(t, eqLen(t, 3, got))
+		eq(t, errors.Remediation{Hint: "run `foo login` first"}, got[0])
+		eq(t, errors.Remediation{Hint: "retry the batch"}, got[1])
+		eq(t, errors.Remediation{HelpURL: "https://example.com/quotas"}, got[2])
+	})
+
+	t.Run("hints are not fields", func(t *testing.T) {
+		err := errors.New("msg", errors.NoFrame, errors.Hint("hint"), errors.HelpURL("https://example.com"))
+		eqFields(t, nil, errors.Fields(err))
+	})
+
+	t.Run("hints are rendered", func(t *testing.T) {
+		err := errors.New("not logged in", errors.NoFrame, errors.Hint("run `foo login` first"), errors.HelpURL("https://example.com/login"))
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.Fprint(&buf, err, errors.PrintOpts{Verbose: true})))
+
+		want := "Error: not logged in\n" +
+			"Hint: run `foo login` first\n" +
+			"Help: https://example.com/login\n" +
+			"\n" +
+			"not logged in hint=\"run `foo login` first\" help=https://example.com/login\n"
+		eq(t, want, buf.String())
+	})
+}
@@ -0,0 +1,42 @@
+package errors
+
+// The following interfaces allow errors defined outside this pkg, (i.e.
+// a domain error type or the errors of another library), to participate
+// in the error handling of this pkg. An error implementing any of them,
+// anywhere in the error tree, contributes to Fields, V, StackTrace and
+// the Kind lookups, the same as the errors of this pkg do.
+
+// Kinder is implemented by errors that report their own Kind. This allows
+// errors defined outside this pkg to be matched by Kind the same as the
+// errors of this pkg. The std lib errors.Is matches a Kinder by Kind only
+// when it is wrapped by an error of this pkg, where the Is func of this pkg
+// and Kind.Is match it anywhere in the error tree.
+//
+//	type notFoundErr struct{ id string }
+//
+//	func (n notFoundErr) Error() string     { return n.id + " not found" }
+//	func (n notFoundErr) Kind() errors.Kind { return "not_found" }
+type Kinder interface {
+	Kind() Kind
+}
+
+// Fielder is implemented by errors that provide logging fields. The fields
+// are key value pairs, the same as provided to KVs. The fields should only
+// include the error's own fields, the fields of the errors it wraps are
+// obtained from the wrapped errors.
+type Fielder interface {
+	Fields() []any
+}
+
+// StackTracer is implemented by errors that provide their own stack trace.
+// The frames are ordered with the origin of the error first, matching the
+// StackTrace method of the errors of this pkg and github.com/pkg/errors.
+type StackTracer interface {
+	StackTrace() StackFrames
+}
+
+// Valuer is implemented by errors that provide values by key. See V for
+// more info.
+type Valuer interface {
+	V(key string) (any, bool)
+}
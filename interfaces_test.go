@@ -0,0 +1,94 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+type domainErr struct {
+	id    string
+	inner error
+}
+
+func (d *domainErr) Error() string     { return "domain err " + d.id }
+func (d *domainErr) Unwrap() error     { return d.inner }
+func (d *domainErr) Kind() errors.Kind { return "domain" }
+func (d *domainErr) Fields() []any     { return []any{"domain_id", d.id} }
+
+func (d *domainErr) V(key string) (any, bool) {
+	if key == "computed" {
+		return "computed_" + d.id, true
+	}
+	return nil, false
+}
+
+func (d *domainErr) StackTrace() errors.StackFrames {
+	return errors.StackFrames{
+		{FilePath: "domain.go", Fn: "pkg.origin", Line: 2},
+		{FilePath: "domain.go", Fn: "pkg.caller", Line: 1},
+	}
+}
+
+func TestForeignInterfaces(t *testing.T) {
+	t.Run("foreign error wrapped by this pkg", func(t *testing.T) {
+		err := errors.Wrap(&domainErr{id: "1"}, errors.NoFrame, errors.KVs("outer", "v"))
+
+		wantFields := []any{
+			"outer", "v",
+			"domain_id", "1",
+			"err_kind", "domain",
+			"stack_trace", []string{"domain.go:1[caller]", "domain.go:2[origin]"},
+		}
+		eqFields(t, wantFields, errors.Fields(err))
+
+		eqV(t, err, "domain_id", "1")
+		eqV(t, err, "computed", "computed_1")
+		eq(t, true, errors.Is(err, errors.Kind("domain")))
+		eq(t, "[ domain.go:1[caller], domain.go:2[origin] ]", errors.StackTrace(err).String())
+	})
+
+	t.Run("foreign error at the root of the tree", func(t *testing.T) {
+		err := &domainErr{id: "2", inner: errors.New("inner", errors.NoFrame, errors.KVs("inner_key", "inner_val"))}
+
+		wantFields := []any{
+			"domain_id", "2",
+			"inner_key", "inner_val",
+			"err_kind", "domain",
+			"stack_trace", []string{"domain.go:1[caller]", "domain.go:2[origin]"},
+		}
+		eqFields(t, wantFields, errors.Fields(err))
+		eqV(t, err, "inner_key", "inner_val")
+	})
+
+	t.Run("foreign wrapper without any of the interfaces", func(t *testing.T) {
+		err := fmt.Errorf("std: %w", errors.New("inner", errors.Kind("inner_kind"), errors.NoFrame, errors.KVs("k", "v")))
+
+		eqFields(t, []any{"k", "v", "err_kind", "inner_kind"}, errors.Fields(err))
+	})
+
+	t.Run("foreign error joined", func(t *testing.T) {
+		err := errors.Join(&domainErr{id: "3"}, errors.NoFrame)
+
+		wantFields := []any{
+			"err_kind", "domain",
+			"err_0", []any{
+				"domain_id", "3",
+				"err_kind", "domain",
+				"stack_trace", []string{"domain.go:1[caller]", "domain.go:2[origin]"},
+			},
+		}
+		eqFields(t, wantFields, errors.Fields(err))
+	})
+
+	t.Run("foreign error in a cyclic tree", func(t *testing.T) {
+		cyclic := &cyclicErr{msg: "cycle"}
+		cyclic.next = errors.Join(cyclic, errors.New("other", errors.NoFrame, errors.KVs("k", "v")), errors.NoFrame)
+
+		wantFields := []any{
+			"multi_err", []any{"err_1", []any{"k", "v"}},
+		}
+		eqFields(t, wantFields, errors.Fields(cyclic))
+	})
+}
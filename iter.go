@@ -0,0 +1,59 @@
+//go:build go1.23
+
+package errors
+
+import (
+	"iter"
+)
+
+// All returns an iterator over every error in the error tree, starting
+// with err itself. The errors are yielded depth first, see Walk for more info.
+//
+//	for node := range errors.All(err) {
+//		// do something with each node
+//	}
+func All(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		Walk(err, func(node error, _ int, _ []int) WalkAction {
+			if !yield(node) {
+				return WalkStop
+			}
+			return WalkContinue
+		})
+	}
+}
+
+// Layers returns an iterator over the Layer of every error in the error
+// tree. This allows for determining which error contributed which message,
+// Kind, KVs and Frame. The layers are yielded depth first, see Walk for
+// more info.
+func Layers(err error) iter.Seq[Layer] {
+	return func(yield func(Layer) bool) {
+		Walk(err, func(node error, depth int, _ []int) WalkAction {
+			if !yield(newLayer(node, depth)) {
+				return WalkStop
+			}
+			return WalkContinue
+		})
+	}
+}
+
+// KVsOf returns an iterator over every KV in the error tree, along with
+// the depth of the error the KV belongs to. Unlike Fields, no intermediate
+// slice is allocated.
+//
+//	for kv, depth := range errors.KVsOf(err) {
+//		// do something with kv
+//	}
+func KVsOf(err error) iter.Seq2[KV, int] {
+	return func(yield func(KV, int) bool) {
+		Walk(err, func(node error, depth int, _ []int) WalkAction {
+			for _, kv := range getErrMeta(node).kvs {
+				if !yield(kv, depth) {
+					return WalkStop
+				}
+			}
+			return WalkContinue
+		})
+	}
+}
@@ -0,0 +1,100 @@
+//go:build go1.23
+
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestAll(t *testing.T) {
+	inner := errors.New("inner", errors.NoFrame)
+	err := errors.Join(
+		fmt.Errorf("std: %w", inner),
+		sentinelErr,
+		errors.NoFrame,
+	)
+
+	var got []error
+	for node := range errors.All(err) {
+		got = append(got, node)
+	}
+
+	must(t, eqLen(t, 4, got))
+	eq(t, err, got[0])
+	eq(t, inner, got[2])
+	eq(t, sentinelErr, got[3])
+
+	t.Run("breaking stops the iteration", func(t *testing.T) {
+		var count int
+		for range errors.All(err) {
+			count++
+			break
+		}
+		eq(t, 1, count)
+	})
+}
+
+func TestLayers(t *testing.T) {
+	err := errors.Wrap(
+		fmt.Errorf("std ctx: %w",
+			errors.New("inner", errors.Kind("inner_kind"), errors.KVs("ik", "iv")),
+		),
+		"outer",
+		errors.KVs("ok", "ov"),
+	)
+
+	var got []errors.Layer
+	for layer := range errors.Layers(err) {
+		got = append(got, layer)
+	}
+	must(t, eqLen(t, 3, got))
+
+	eq(t, "outer", got[0].Msg)
+	eq(t, 0, got[0].Depth)
+	eq(t, errors.Kind(""), got[0].Kind)
+	eqFields(t, []any{"ok", "ov"}, kvsToFields(got[0].KVs))
+	eq(t, 41, got[0].Frame.Line)
+
+	eq(t, "std ctx", got[1].Msg)
+	eq(t, 1, got[1].Depth)
+	eq(t, errors.Frame{}, got[1].Frame)
+
+	eq(t, "inner", got[2].Msg)
+	eq(t, 2, got[2].Depth)
+	eq(t, errors.Kind("inner_kind"), got[2].Kind)
+	eqFields(t, []any{"ik", "iv"}, kvsToFields(got[2].KVs))
+	eq(t, 43, got[2].Frame.Line)
+}
+
+func TestKVsOf(t *testing.T) {
+	err := errors.Wrap(
+		errors.Join(
+			errors.New("first", errors.KVs("k1", "v1", "k2", "v2")),
+			errors.New("second", errors.KVs("k3", "v3")),
+		),
+		errors.KVs("k0", "v0"),
+	)
+
+	var (
+		got    []any
+		depths []int
+	)
+	for kv, depth := range errors.KVsOf(err) {
+		got = append(got, kv.K, kv.V)
+		depths = append(depths, depth)
+	}
+
+	eqFields(t, []any{"k0", "v0", "k1", "v1", "k2", "v2", "k3", "v3"}, got)
+	eq(t, fmt.Sprint([]int{0, 2, 2, 2}), fmt.Sprint(depths))
+}
+
+func kvsToFields(kvs []errors.KV) []any {
+	var out []any
+	for _, kv := range kvs {
+		out = append(out, kv.K, kv.V)
+	}
+	return out
+}
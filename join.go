@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
 	"strings"
 )
 
@@ -13,6 +14,8 @@ func newJoinE(opts ...any) error {
 		baseOpts = make([]any, 1, len(opts)+1)
 		errs     []error
 		formatFn = listFormatFn
+		flatten  JoinFlatten
+		dedup    JoinDedup
 	)
 	// since we're calling newE from 3 frames away instead of 2
 	baseOpts[0] = SkipCaller
@@ -33,6 +36,10 @@ func newJoinE(opts ...any) error {
 			continue
 		}
 		switch v := o.(type) {
+		case Kind:
+			// Kind satisfies the error interface, it must be matched
+			// before the errors to be used as the join's kind.
+			baseOpts = append(baseOpts, v)
 		case error:
 			addErrs(v)
 		case []error:
@@ -41,10 +48,20 @@ func newJoinE(opts ...any) error {
 			if v != nil {
 				formatFn = v
 			}
+		case JoinFlatten:
+			flatten = v
+		case JoinDedup:
+			dedup = v
 		default:
 			baseOpts = append(baseOpts, o)
 		}
 	}
+	if flatten {
+		errs = flattenErrs(errs, nil)
+	}
+	if dedup > 0 {
+		errs = dedupErrs(errs, dedup)
+	}
 	if len(errs) == 0 {
 		return nil
 	}
@@ -57,7 +74,96 @@ func newJoinE(opts ...any) error {
 		kind:     ee.kind,
 		errs:     errs,
 		kvs:      ee.kvs,
+		hints:    ee.hints,
+		userMsg:  ee.userMsg,
+	}
+}
+
+// flattenErrs replaces the joins amongst the errs with the errors they join.
+func flattenErrs(errs []error, out []error) []error {
+	for _, err := range errs {
+		multi, ok := err.(interface{ Unwrap() []error })
+		if !ok {
+			out = append(out, err)
+			continue
+		}
+		out = flattenErrs(multi.Unwrap(), out)
 	}
+	return out
+}
+
+// dedupErrs removes the duplicate errs, retaining the first of each. The
+// survivors with duplicates are wrapped with a countedErr.
+func dedupErrs(errs []error, dedup JoinDedup) []error {
+	var (
+		out    []error
+		counts []int
+		seen   = make(map[any]int)
+	)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var key any
+		switch dedup {
+		case DedupIdentity:
+			if reflect.TypeOf(err).Comparable() {
+				key = err
+			}
+		case DedupMessage:
+			key = err.Error()
+		case DedupFingerprint:
+			key = fingerprint(err)
+		}
+		if key == nil {
+			out, counts = append(out, err), append(counts, 1)
+			continue
+		}
+
+		if idx, ok := seen[key]; ok {
+			counts[idx]++
+			continue
+		}
+		seen[key] = len(out)
+		out, counts = append(out, err), append(counts, 1)
+	}
+
+	for i, count := range counts {
+		if count > 1 {
+			out[i] = &countedErr{err: out[i], count: count}
+		}
+	}
+	return out
+}
+
+// countedErr provides the number of times a deduplicated error was joined as
+// a count field, leaving the Error output of the error untouched.
+type countedErr struct {
+	err   error
+	count int
+}
+
+func (c *countedErr) Error() string { return c.err.Error() }
+func (c *countedErr) Unwrap() error { return c.err }
+func (c *countedErr) Fields() []any { return []any{"count", c.count} }
+
+// fingerprint identifies how an error fails, by way of the type, Kind
+// and Frame of each error in its tree. Errors without a Frame have no
+// location to speak of, so their message is used in its place.
+func fingerprint(err error) string {
+	var sb strings.Builder
+	Walk(err, func(node error, depth int, _ []int) WalkAction {
+		em := getErrMeta(node)
+		fmt.Fprintf(&sb, "%d|%T|%s|", depth, node, em.kind)
+		if em.frame.FilePath != "" {
+			fmt.Fprintf(&sb, "%s:%d;", em.frame.FilePath, em.frame.Line)
+		} else {
+			fmt.Fprintf(&sb, "%q;", layerMsg(node))
+		}
+		return WalkContinue
+	})
+	return sb.String()
 }
 
 type joinE struct {
@@ -76,6 +182,9 @@ type joinE struct {
 	//	   calling something like Meta/Fields on the error? Then have a specific
 	//	   function for getting the logging fields (i.e. everything to []any)
 	kvs []KV
+
+	hints   []Remediation
+	userMsg string
 }
 
 func (err *joinE) Error() string {
@@ -95,6 +204,10 @@ func (err *joinE) Format(s fmt.State, verb rune) {
 }
 
 func (err *joinE) Fields() []any {
+	return err.fields(nil)
+}
+
+func (err *joinE) fields(ancestors []error) []any {
 	var (
 		out  []any
 		kind = err.kind
@@ -103,7 +216,7 @@ func (err *joinE) Fields() []any {
 		out = append(out, kv.K, kv.V)
 	}
 
-	innerKind, subErrFields := err.subErrFields()
+	innerKind, subErrFields := subErrFields(err.errs, append(ancestors[:len(ancestors):len(ancestors)], err))
 	kind = cmp.Or(kind, innerKind)
 	if kind != "" {
 		out = append(out, "err_kind", string(kind))
@@ -122,27 +235,47 @@ func (err *joinE) Fields() []any {
 	return out
 }
 
-func (err *joinE) subErrFields() (Kind, []any) {
+// subErrFields returns the fields for each of the joined errors, keyed by
+// their index, along with the first Kind found amongst them. Joined errors
+// that are amongst the ancestors of the join are skipped, protecting against
+// cycles in the tree.
+func subErrFields(errs []error, ancestors []error) (Kind, []any) {
 	var (
-		kind         Kind
-		subErrFields []any
+		kind   Kind
+		fields []any
 	)
-	for i, err := range err.errs {
+	for i, err := range errs {
+		if err == nil || isAncestor(err, ancestors) {
+			continue
+		}
+
 		var errFields []any
 		switch err := err.(type) {
 		case *e:
-			errFields = err.Fields()
+			errFields = treeFields(err, err.stackTrace(), ancestors)
 		case *joinE:
+			errFields = err.fields(ancestors)
+		case chain:
 			errFields = err.Fields()
+		case interface{ Unwrap() []error }:
+			_, errFields = subErrFields(err.Unwrap(), append(ancestors[:len(ancestors):len(ancestors)], errs[i]))
+		default:
+			errFields = treeFields(err, StackTrace(err), ancestors)
 		}
 		if len(errFields) > 0 {
-			subErrFields = append(subErrFields, fmt.Sprintf("err_%d", i), errFields)
+			fields = append(fields, fmt.Sprintf("err_%d", i), errFields)
 		}
 		if innerKind := getKind(err); kind == "" && innerKind != "" {
 			kind = innerKind
 		}
 	}
-	return kind, subErrFields
+	return kind, fields
+}
+
+// StackTrace returns the stack frames of the error in the order of github.com/pkg/errors'
+// StackTrace. See (*e).StackTrace for more info.
+func (err *joinE) StackTrace() StackFrames {
+	return pkgErrorsOrder(err.stackTrace())
 }
 
 func (err *joinE) stackTrace() StackFrames {
@@ -152,15 +285,41 @@ func (err *joinE) stackTrace() StackFrames {
 	return StackFrames{err.frame}
 }
 
-// Unwrap returns an error from Error (or nil if there are no errors).
+// Unwrap returns the joined errors. This allows the std lib errors.Is/As
+// to traverse all the joined errors, as it does for the std lib errors.Join.
+func (err *joinE) Unwrap() []error {
+	if err == nil {
+		return nil
+	}
+	return err.errs
+}
+
+// V returns the value for the key from the join's KVs, or the first
+// joined error that has a matching key.
+func (err *joinE) V(key string) (any, bool) {
+	return lookupV(err, key)
+}
+
+// Is matches the Kind of the join itself. The joined errors are matched
+// by the std lib errors.Is, via Unwrap.
+func (err *joinE) Is(target error) bool {
+	kind, ok := target.(Kind)
+	if !ok || kind == "" {
+		return false
+	}
+	return err.kind == kind || wrapsForeignKind(err, kind)
+}
+
+// unwrapChain returns an error from Error (or nil if there are no errors).
 // This error returned will further support Unwrap to get the next error,
 // etc. The order will match the order of errors provided when calling Join.
+// This is what the Unwrap func of this pkg returns for a joined error.
 //
 // The resulting error supports errors.As/Is/Unwrap so you can continue
 // to use the stdlib errors package to introspect further.
 //
 // The is borrowed from hashi/go-multierror module.
-func (err *joinE) Unwrap() error {
+func (err *joinE) unwrapChain() error {
 	if err == nil || len(err.errs) == 0 {
 		return nil
 	}
@@ -239,7 +398,7 @@ func (e chain) Is(target error) bool {
 
 // listFormatFn borrowed from hashi go-multierror module.
 func listFormatFn(msg string, errs []error) string {
-	if msg == "" && len(errs) == 1 {
+	if msg == "" && errCount(errs) == 1 {
 		return fmt.Sprintf("1 error occurred:\n\t* %s\n", errs[0])
 	}
 
@@ -249,7 +408,7 @@ func listFormatFn(msg string, errs []error) string {
 	}
 
 	if msg == "" {
-		msg = fmt.Sprintf("%d errors occurred:\n\t", len(errs))
+		msg = fmt.Sprintf("%d errors occurred:\n\t", errCount(errs))
 	}
 	return fmt.Sprintf("%s%s\n", msg, strings.Join(points, "\n\t"))
 }
@@ -0,0 +1,153 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The following JoinFormatFns may be provided to Join to customize the
+// text output of the joined errors.
+//
+//	errors.Join(errs, errors.SemicolonFormat)
+var (
+	// ListFormat formats the errors as a bulleted list, this is the
+	// default format of a joined error.
+	ListFormat JoinFormatFn = listFormatFn
+
+	// NewlineFormat formats the errors one per line, matching the std lib
+	// errors.Join. When provided, the join's message is the first line.
+	NewlineFormat JoinFormatFn = newlineFormatFn
+
+	// SemicolonFormat formats the errors on a single line, separated by
+	// semicolons. This is useful for log lines and similar.
+	SemicolonFormat JoinFormatFn = semicolonFormatFn
+
+	// NumberedFormat formats the errors as a numbered list.
+	NumberedFormat JoinFormatFn = numberedFormatFn
+
+	// TreeFormat formats the errors as an indented tree, where nested joins
+	// are formatted as branches of the tree.
+	TreeFormat JoinFormatFn = treeFormatFn
+)
+
+// TruncateFormat limits the number of errors formatted by the JoinFormatFn
+// to max. The remaining errors are summarized as a final "… and 37 more
+// errors" entry. When the JoinFormatFn is nil, ListFormat is used.
+//
+//	errors.Join(errs, errors.TruncateFormat(10, errors.NumberedFormat))
+func TruncateFormat(max int, fn JoinFormatFn) JoinFormatFn {
+	if fn == nil {
+		fn = listFormatFn
+	}
+	return func(msg string, errs []error) string {
+		if max < 0 || len(errs) <= max {
+			return fn(msg, errs)
+		}
+		return fn(msg, append(errs[:max:max], truncatedErr(len(errs)-max)))
+	}
+}
+
+// truncatedErr stands in for the errors dropped by TruncateFormat.
+type truncatedErr int
+
+func (t truncatedErr) Error() string {
+	if t == 1 {
+		return "… and 1 more error"
+	}
+	return fmt.Sprintf("… and %d more errors", int(t))
+}
+
+// errCount returns the number of errors, including those dropped by
+// TruncateFormat.
+func errCount(errs []error) int {
+	var n int
+	for _, err := range errs {
+		if t, ok := err.(truncatedErr); ok {
+			n += int(t)
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+func newlineFormatFn(msg string, errs []error) string {
+	lines := make([]string, 0, len(errs)+1)
+	if msg != "" {
+		lines = append(lines, msg)
+	}
+	for _, err := range errs {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func semicolonFormatFn(msg string, errs []error) string {
+	points := make([]string, len(errs))
+	for i, err := range errs {
+		points[i] = err.Error()
+	}
+
+	out := strings.Join(points, "; ")
+	if msg != "" {
+		out = msg + ": " + out
+	}
+	return out
+}
+
+func numberedFormatFn(msg string, errs []error) string {
+	points := make([]string, len(errs))
+	for i, err := range errs {
+		points[i] = strconv.Itoa(i+1) + ". " + err.Error()
+		if _, ok := err.(truncatedErr); ok {
+			points[i] = err.Error()
+		}
+	}
+
+	if msg == "" {
+		msg = errsOccurred(errs)
+	}
+	return fmt.Sprintf("%s:\n\t%s\n", msg, strings.Join(points, "\n\t"))
+}
+
+func treeFormatFn(msg string, errs []error) string {
+	if msg == "" {
+		msg = errsOccurred(errs)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(msg + ":\n")
+	writeErrTree(&sb, errs, "")
+	return sb.String()
+}
+
+func writeErrTree(sb *strings.Builder, errs []error, prefix string) {
+	for i, err := range errs {
+		connector, childPrefix := "├── ", prefix+"│   "
+		if i == len(errs)-1 {
+			connector, childPrefix = "└── ", prefix+"    "
+		}
+
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			nested := multi.Unwrap()
+			msg := layerMsg(err)
+			if msg == "" {
+				msg = errsOccurred(nested)
+			}
+			sb.WriteString(prefix + connector + msg + ":\n")
+			writeErrTree(sb, nested, childPrefix)
+			continue
+		}
+
+		lines := strings.Split(strings.TrimSuffix(err.Error(), "\n"), "\n")
+		sb.WriteString(prefix + connector + strings.Join(lines, "\n"+childPrefix) + "\n")
+	}
+}
+
+func errsOccurred(errs []error) string {
+	if n := errCount(errs); n != 1 {
+		return fmt.Sprintf("%d errors occurred", n)
+	}
+	return "1 error occurred"
+}
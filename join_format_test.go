@@ -0,0 +1,96 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestJoinFormatFns(t *testing.T) {
+	errs := []error{fmt.Errorf("first"), fmt.Errorf("second"), fmt.Errorf("third")}
+
+	tests := []struct {
+		name string
+		opts []any
+		want string
+	}{
+		{
+			name: "list",
+			opts: []any{errors.ListFormat},
+			want: "3 errors occurred:\n\t* first\n\t* second\n\t* third\n",
+		},
+		{
+			name: "newline matches std lib",
+			opts: []any{errors.NewlineFormat},
+			want: "first\nsecond\nthird",
+		},
+		{
+			name: "newline with msg",
+			opts: []any{errors.NewlineFormat, "batch failed"},
+			want: "batch failed\nfirst\nsecond\nthird",
+		},
+		{
+			name: "semicolon",
+			opts: []any{errors.SemicolonFormat},
+			want: "first; second; third",
+		},
+		{
+			name: "semicolon with msg",
+			opts: []any{errors.SemicolonFormat, "batch failed"},
+			want: "batch failed: first; second; third",
+		},
+		{
+			name: "numbered",
+			opts: []any{errors.NumberedFormat},
+			want: "3 errors occurred:\n\t1. first\n\t2. second\n\t3. third\n",
+		},
+		{
+			name: "truncated list",
+			opts: []any{errors.TruncateFormat(1, nil)},
+			want: "3 errors occurred:\n\t* first\n\t* … and 2 more errors\n",
+		},
+		{
+			name: "truncated numbered",
+			opts: []any{errors.TruncateFormat(2, errors.NumberedFormat)},
+			want: "3 errors occurred:\n\t1. first\n\t2. second\n\t… and 1 more error\n",
+		},
+		{
+			name: "truncated within max",
+			opts: []any{errors.TruncateFormat(3, errors.SemicolonFormat)},
+			want: "first; second; third",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.Join(append([]any{errs, errors.NoFrame}, tt.opts...)...)
+			eq(t, tt.want, err.Error())
+		})
+	}
+
+	t.Run("tree formats nested joins as branches", func(t *testing.T) {
+		err := errors.Join(
+			fmt.Errorf("first"),
+			errors.Join(
+				fmt.Errorf("nested 1"),
+				fmt.Errorf("multi\nline"),
+				"nested batch",
+				errors.NoFrame,
+			),
+			fmt.Errorf("third"),
+			errors.TreeFormat,
+			errors.NoFrame,
+		)
+
+		want := `3 errors occurred:
+├── first
+├── nested batch:
+│   ├── nested 1
+│   └── multi
+│       line
+└── third
+`
+		eq(t, want, err.Error())
+	})
+}
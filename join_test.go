@@ -136,3 +136,155 @@ func TestDisjoin(t *testing.T) {
 		eq(t, innerErr, errs[0])
 	})
 }
+
+func TestJoin_MultiUnwrap(t *testing.T) {
+	t.Run("std lib traverses all joined errors", func(t *testing.T) {
+		first, second := fmt.Errorf("first"), fmt.Errorf("second")
+		err := errors.Join(first, errors.Wrap(second))
+
+		multi, ok := err.(interface{ Unwrap() []error })
+		must(t, eq(t, true, ok))
+		must(t, eqLen(t, 2, multi.Unwrap()))
+		eq(t, first, multi.Unwrap()[0])
+
+		eq(t, nil, stderrors.Unwrap(err))
+		eq(t, true, stderrors.Is(err, second))
+	})
+
+	t.Run("kinds are matched in either argument order", func(t *testing.T) {
+		err := errors.Wrap(errors.Join(
+			fmt.Errorf("first"),
+			errors.Join(errors.New("nested", errors.Kind("nested_kind"))),
+			errors.Kind("join_kind"),
+		))
+
+		for _, kind := range []errors.Kind{"nested_kind", "join_kind"} {
+			eq(t, true, errors.Is(err, kind))
+			eq(t, true, errors.Is(kind, err))
+		}
+		eq(t, false, errors.Is(err, errors.Kind("other")))
+		eq(t, false, errors.Is(errors.Kind("other"), err))
+	})
+
+	t.Run("V searches joined errors", func(t *testing.T) {
+		err := errors.Wrap(errors.Join(
+			fmt.Errorf("first"),
+			stderrors.Join(errors.New("std joined", errors.KVs("std_key", "std_val"))),
+			errors.Join(errors.New("nested", errors.KVs("nested_key", "nested_val"))),
+			errors.KVs("join_key", "join_val"),
+		))
+
+		eqV(t, err, "join_key", "join_val")
+		eqV(t, err, "nested_key", "nested_val")
+		eqV(t, err, "std_key", "std_val")
+		eq(t, nil, errors.V(err, "missing"))
+	})
+
+	t.Run("Fields include std lib joined errors", func(t *testing.T) {
+		err := errors.Wrap(
+			stderrors.Join(errors.New("std joined", errors.NoFrame, errors.Kind("inner"), errors.KVs("k", "v"))),
+			errors.NoFrame,
+		)
+
+		wantFields := []any{
+			"multi_err", []any{"err_0", []any{"k", "v", "err_kind", "inner"}},
+			"err_kind", "inner",
+		}
+		eqFields(t, wantFields, errors.Fields(err))
+	})
+
+	t.Run("StackTrace is found through foreign wrappers", func(t *testing.T) {
+		err := fmt.Errorf("foreign: %w", errors.New("inner"))
+
+		frames := errors.StackTrace(err)
+		must(t, eqLen(t, 1, frames))
+		eq(t, 197, frames[0].Line)
+	})
+}
+
+func TestJoin_FlattenAndDedup(t *testing.T) {
+	t.Run("flatten lifts nested joins into a single level", func(t *testing.T) {
+		first, second, third := fmt.Errorf("first"), fmt.Errorf("second"), fmt.Errorf("third")
+		err := errors.Join(
+			first,
+			errors.Join(second, stderrors.Join(third), errors.Kind("dropped")),
+			errors.Flatten,
+			errors.NoFrame,
+		)
+
+		errs := errors.Disjoin(err)
+		must(t, eqLen(t, 3, errs))
+		eq(t, first, errs[0])
+		eq(t, second, errs[1])
+		eq(t, third, errs[2])
+	})
+
+	t.Run("dedup by identity", func(t *testing.T) {
+		other := fmt.Errorf("other")
+		err := errors.Join(sentinelErr, other, sentinelErr, sentinelErr, errors.DedupIdentity, errors.NoFrame)
+
+		errs := errors.Disjoin(err)
+		must(t, eqLen(t, 2, errs))
+		eq(t, true, errors.Is(errs[0], sentinelErr))
+		eq(t, "sentinel err", errs[0].Error())
+		eq(t, "2 errors occurred:\n\t* sentinel err\n\t* other\n", err.Error())
+		eqV(t, errs[0], "count", 3)
+		eq(t, other, errs[1])
+		eq(t, nil, errors.V(errs[1], "count"))
+	})
+
+	t.Run("dedup by message", func(t *testing.T) {
+		err := errors.Join(
+			fmt.Errorf("same"),
+			fmt.Errorf("same"),
+			fmt.Errorf("different"),
+			errors.DedupMessage,
+			errors.NoFrame,
+		)
+
+		errs := errors.Disjoin(err)
+		must(t, eqLen(t, 2, errs))
+		eq(t, "same", errs[0].Error())
+		eqV(t, errs[0], "count", 2)
+		eq(t, "different", errs[1].Error())
+	})
+
+	t.Run("dedup by fingerprint", func(t *testing.T) {
+		newNotFound := func(id int) error {
+			return errors.New(fmt.Sprintf("user %d not found", id), errors.Kind("not_found"))
+		}
+
+		var errs []error
+		for id := range 10_000 {
+			errs = append(errs, newNotFound(id))
+		}
+		errs = append(errs,
+			errors.New("user 1 not found", errors.Kind("not_found")),
+			fmt.Errorf("std 1"),
+			fmt.Errorf("std 2"),
+		)
+
+		got := errors.Disjoin(errors.Join(errs, errors.DedupFingerprint, errors.NoFrame))
+		must(t, eqLen(t, 4, got))
+		eq(t, "user 0 not found", got[0].Error())
+		eqV(t, got[0], "count", 10_000)
+		eq(t, true, errors.Is(got[0], errors.Kind("not_found")))
+		eq(t, nil, errors.V(got[1], "count"))
+		eq(t, "std 1", got[2].Error())
+		eq(t, "std 2", got[3].Error())
+	})
+
+	t.Run("flatten and dedup combined", func(t *testing.T) {
+		err := errors.Join(
+			sentinelErr,
+			errors.Join(sentinelErr, sentinelErr),
+			errors.Flatten,
+			errors.DedupIdentity,
+			errors.NoFrame,
+		)
+
+		errs := errors.Disjoin(err)
+		must(t, eqLen(t, 1, errs))
+		eqV(t, errs[0], "count", 3)
+	})
+}
@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Layer is a single error of an error tree, along with the context that
+// error contributes to the tree.
+type Layer struct {
+	// Msg is the message contributed by this error alone, excluding the
+	// messages of the errors it wraps.
+	Msg   string
+	Kind  Kind
+	KVs   []KV
+	Frame Frame
+
+	// StdKVs are the details extracted from the std lib error types, (i.e.
+	// the path of an *fs.PathError). These are kept apart from the KVs, as
+	// they often hold the internals of a system, such as file paths and
+	// addresses, that are meant for logging alone. Fields and V include them.
+	StdKVs []KV
+
+	// Hints are the remediations provided to this error. See Hint and
+	// HelpURL for more info.
+	Hints []Remediation
+
+	// UserMsg is the UserMessage provided to this error. See PublicMessage
+	// for more info.
+	UserMsg string
+
+	// Type is the concrete type name of the error, (i.e. *fmt.wrapError).
+	Type string
+
+	// Depth is the number of unwraps taken to reach this error from the
+	// root error. See Walk for more info.
+	Depth int
+
+	// Children contains the layers of each joined error, in the order they
+	// were joined. Children is only populated by Inspect, and is nil for
+	// errors that do not join multiple errors.
+	Children [][]Layer
+}
+
+// Inspect returns the layers of the error, starting with err itself and
+// following each wrapped error. Errors joining multiple errors end the
+// layers, with the layers of each joined error provided in the Children
+// of the join's layer. Inspect is useful for reconstructing which wrap
+// contributed which context, as Error and Fields flatten the layers.
+func Inspect(err error) []Layer {
+	return inspect(err, 0, nil)
+}
+
+func inspect(err error, depth int, ancestors []error) []Layer {
+	var layers []Layer
+	for err != nil && !isAncestor(err, ancestors) {
+		layer := newLayer(err, depth)
+		ancestors = append(ancestors, err)
+
+		if !isMulti(err) {
+			layers = append(layers, layer)
+			if next := children(err); len(next) > 0 {
+				err, depth = next[0], depth+1
+				continue
+			}
+			break
+		}
+
+		for _, child := range children(err) {
+			if child == nil {
+				continue
+			}
+			layer.Children = append(layer.Children, inspect(child, depth+1, ancestors[:len(ancestors):len(ancestors)]))
+		}
+		layers = append(layers, layer)
+		break
+	}
+	return layers
+}
+
+func newLayer(err error, depth int) Layer {
+	em := getErrMeta(err)
+	return Layer{
+		Msg:     layerMsg(err),
+		Kind:    em.kind,
+		KVs:     em.kvs[:len(em.kvs)-len(em.stdKVs)],
+		StdKVs:  em.stdKVs,
+		Frame:   em.frame,
+		Hints:   em.hints,
+		UserMsg: em.userMsg,
+		Type:    fmt.Sprintf("%T", err),
+		Depth:   depth,
+	}
+}
+
+func isMulti(err error) bool {
+	switch err.(type) {
+	case chain, interface{ Unwrap() []error }:
+		return true
+	}
+	return false
+}
+
+// layerMsg returns the message an error contributes, excluding the message
+// of the errors it wraps. For foreign errors wrapping another error, (i.e.
+// fmt.Errorf("ctx: %w", err)), the wrapped error's message is trimmed off.
+func layerMsg(err error) string {
+	switch err := err.(type) {
+	case *e:
+		return err.msg
+	case *joinE:
+		return err.msg
+	}
+	if isMulti(err) {
+		return ""
+	}
+
+	msg := err.Error()
+	inner := errors.Unwrap(err)
+	if inner == nil {
+		return msg
+	}
+
+	// fmt.Errorf formats the wrapped error with %v, which for the errors
+	// of this pkg includes the stack trace, so both forms are checked.
+	for _, innerMsg := range []string{inner.Error(), fmt.Sprintf("%v", inner)} {
+		if trimmed, ok := strings.CutSuffix(msg, innerMsg); ok {
+			return strings.TrimSuffix(strings.TrimSpace(trimmed), ":")
+		}
+	}
+	return msg
+}
@@ -0,0 +1,77 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestInspect(t *testing.T) {
+	t.Run("with nil error should return nil", func(t *testing.T) {
+		eqLen(t, 0, errors.Inspect(nil))
+	})
+
+	t.Run("wrapped errors are provided one layer each", func(t *testing.T) {
+		err := errors.Wrap(
+			fmt.Errorf("std ctx: %w", errors.New("inner", errors.Kind("inner_kind"))),
+			"outer",
+			errors.KVs("ok", "ov"),
+		)
+
+		layers := errors.Inspect(err)
+		must(t, eqLen(t, 3, layers))
+
+		eq(t, "outer", layers[0].Msg)
+		eq(t, "*errors.e", layers[0].Type)
+		eq(t, 0, layers[0].Depth)
+		eq(t, 16, layers[0].Frame.Line)
+		eqFields(t, []any{"ok", "ov"}, kvsToFields(layers[0].KVs))
+
+		eq(t, "std ctx", layers[1].Msg)
+		eq(t, "*fmt.wrapError", layers[1].Type)
+		eq(t, 1, layers[1].Depth)
+
+		eq(t, "inner", layers[2].Msg)
+		eq(t, errors.Kind("inner_kind"), layers[2].Kind)
+		eq(t, 2, layers[2].Depth)
+		eq(t, 17, layers[2].Frame.Line)
+
+		for _, layer := range layers {
+			eqLen(t, 0, layer.Children)
+		}
+	})
+
+	t.Run("joined errors are provided as children", func(t *testing.T) {
+		err := errors.Wrap(
+			errors.Join(
+				errors.Wrap(errors.New("first"), "wrapped"),
+				sentinelErr,
+				errors.Kind("join_kind"),
+			),
+			"outer",
+		)
+
+		layers := errors.Inspect(err)
+		must(t, eqLen(t, 2, layers))
+		eq(t, "outer", layers[0].Msg)
+
+		join := layers[1]
+		eq(t, "*errors.joinE", join.Type)
+		eq(t, errors.Kind("join_kind"), join.Kind)
+		must(t, eqLen(t, 2, join.Children))
+
+		first := join.Children[0]
+		must(t, eqLen(t, 2, first))
+		eq(t, "wrapped", first[0].Msg)
+		eq(t, 2, first[0].Depth)
+		eq(t, "first", first[1].Msg)
+		eq(t, 3, first[1].Depth)
+		eq(t, 48, first[1].Frame.Line)
+
+		second := join.Children[1]
+		must(t, eqLen(t, 1, second))
+		eq(t, "sentinel err", second[0].Msg)
+		eq(t, "*errors.errorString", second[0].Type)
+	})
+}
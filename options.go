@@ -23,6 +23,39 @@ const (
 // the text output when calling Error() on the join error.
 type JoinFormatFn func(msg string, errs []error) string
 
+// JoinFlatten marks the joined errors to be flattened into a single level.
+// Joined errors that are themselves joins, (i.e. Join or the std lib errors.Join),
+// are replaced by the errors they join. The message, Kind and KVs of the
+// flattened joins are not retained.
+type JoinFlatten bool
+
+// Flatten flattens nested joins into a single level when provided to Join.
+//
+//	errors.Join(errs, errors.Flatten)
+const Flatten JoinFlatten = true
+
+// JoinDedup marks how Join de-duplicates the errors it joins. The first
+// of the duplicate errors survives, and is provided a "count" KV with the
+// number of duplicates it represents, leaving its Error() text unchanged. The
+// errors are de-duplicated after they are flattened, when Flatten is provided.
+type JoinDedup int
+
+const (
+	// DedupIdentity de-duplicates errors that are equal to one another,
+	// (i.e. the same sentinel error joined many times).
+	DedupIdentity JoinDedup = iota + 1
+
+	// DedupMessage de-duplicates errors with the same Error() text.
+	DedupMessage
+
+	// DedupFingerprint de-duplicates errors that fail the same way. Two
+	// errors share a fingerprint when each error in their trees is of the
+	// same type, Kind and Frame. This allows errors created from the same
+	// line of code, with messages that differ by an id or similar, to be
+	// de-duplicated. Errors without a Frame are compared by their message.
+	DedupFingerprint
+)
+
 // Kind represents the category of the error type. A few examples of
 // error kinds are as follows:
 //
@@ -54,10 +87,12 @@ func (k Kind) Error() string {
 }
 
 // Is determines if the error's kind matches. To be used with the std
-// lib errors.Is function.
+// lib errors.Is function. The entire error tree of the target is searched,
+// including joined errors and errors implementing Kinder, so that
+// errors.Is(kind, err) matches the same errors that errors.Is(err, kind)
+// does.
 func (k Kind) Is(target error) bool {
-	ee, ok := target.(*e)
-	return ok && ee.kind == k
+	return hasKind(target, k)
 }
 
 // KV provides context to the error. These can be triggered by different
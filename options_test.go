@@ -2,6 +2,7 @@ package errors_test
 
 import (
 	stderrors "errors"
+	"fmt"
 	"testing"
 
 	"github.com/jsteenb2/errors"
@@ -13,3 +14,91 @@ func TestKind_Is(t *testing.T) {
 	matches := stderrors.Is(errors.Kind("first"), err)
 	eq(t, true, matches)
 }
+
+type kinderErr struct{ kind errors.Kind }
+
+func (k kinderErr) Error() string     { return "kinder err" }
+func (k kinderErr) Kind() errors.Kind { return k.kind }
+
+func TestKind_IsAcrossWrappers(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+
+		// stdIs marks the errors the std lib errors.Is matches with the
+		// kind as the target. A foreign Kinder is only matched when it is
+		// wrapped by an error of this pkg.
+		stdIs bool
+	}{
+		{
+			name:  "joined error",
+			err:   errors.Join(sentinelErr, errors.New("joined", errors.Kind("first"))),
+			stdIs: true,
+		},
+		{
+			name:  "join kind",
+			err:   errors.Join(sentinelErr, errors.Kind("first")),
+			stdIs: true,
+		},
+		{
+			name:  "unwrapped join chain",
+			err:   errors.Unwrap(errors.Join(sentinelErr, errors.New("chained", errors.Kind("first")))),
+			stdIs: true,
+		},
+		{
+			name:  "std lib joined error",
+			err:   stderrors.Join(sentinelErr, errors.New("std joined", errors.Kind("first"))),
+			stdIs: true,
+		},
+		{
+			name:  "foreign Kinder",
+			err:   kinderErr{kind: "first"},
+			stdIs: false,
+		},
+		{
+			name:  "std lib joined foreign Kinder",
+			err:   stderrors.Join(sentinelErr, kinderErr{kind: "first"}),
+			stdIs: false,
+		},
+		{
+			name:  "directly wrapped foreign Kinder",
+			err:   errors.Wrap(kinderErr{kind: "first"}),
+			stdIs: true,
+		},
+		{
+			name:  "wrapped foreign Kinder",
+			err:   errors.Wrap(fmt.Errorf("foreign: %w", kinderErr{kind: "first"})),
+			stdIs: true,
+		},
+		{
+			name:  "joined foreign Kinder",
+			err:   errors.Join(sentinelErr, kinderErr{kind: "first"}),
+			stdIs: true,
+		},
+		{
+			name:  "foreign Kinder wrapped by std lib wrapper of this pkg's error",
+			err:   fmt.Errorf("outer: %w", errors.Wrap(kinderErr{kind: "first"}, "inner")),
+			stdIs: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eq(t, true, stderrors.Is(errors.Kind("first"), tt.err))
+			eq(t, true, errors.Is(tt.err, errors.Kind("first")))
+			eq(t, true, errors.Is(errors.Kind("first"), tt.err))
+			eq(t, tt.stdIs, stderrors.Is(tt.err, errors.Kind("first")))
+
+			eq(t, false, stderrors.Is(errors.Kind("other"), tt.err))
+			eq(t, false, errors.Is(tt.err, errors.Kind("other")))
+			eq(t, false, errors.Is(errors.Kind("other"), tt.err))
+			eq(t, false, stderrors.Is(tt.err, errors.Kind("other")))
+		})
+	}
+
+	t.Run("empty kind does not match errors without a kind", func(t *testing.T) {
+		err := errors.New("no kind")
+		eq(t, false, stderrors.Is(err, errors.Kind("")))
+		eq(t, false, stderrors.Is(errors.Kind(""), err))
+	})
+}
@@ -0,0 +1,155 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// The following provide API compatibility with github.com/pkg/errors. This
+// makes migrating from github.com/pkg/errors as simple as swapping out the
+// import path. Each captures a stack frame, the same as Wrap.
+
+// Cause returns the underlying cause of the error. The cause is found by
+// unwrapping the error until an error that does not wrap another error is
+// found. Errors implementing github.com/pkg/errors' causer interface are
+// honored as well. Joined errors are considered the cause, as they have
+// no single underlying cause to speak of.
+func Cause(err error) error {
+	for err != nil {
+		switch err.(type) {
+		case *joinE, interface{ Unwrap() []error }:
+			return err
+		}
+
+		var next error
+		switch t := err.(type) {
+		case interface{ Cause() error }:
+			next = t.Cause()
+		case interface{ Unwrap() error }:
+			next = t.Unwrap()
+		}
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+	return nil
+}
+
+// Errorf creates a new error with the formatted message. Similar to
+// fmt.Errorf, the %w verb may be used to wrap one or more errors. The
+// errors are formatted with their Error text, so the stack frames of the
+// errors of this pkg are not included in the message.
+func Errorf(format string, args ...any) error {
+	plainArgs := make([]any, len(args))
+	for i, arg := range args {
+		plainArgs[i] = arg
+		if err, ok := arg.(error); ok && !isNilValue(err) {
+			plainArgs[i] = errText{err: err}
+		}
+	}
+
+	err := fmt.Errorf(format, plainArgs...)
+	switch t := err.(type) {
+	case interface{ Unwrap() []error }:
+		var errs []error
+		for _, wrapped := range t.Unwrap() {
+			errs = append(errs, fromErrText(wrapped))
+		}
+		return Wrap(&fmtErrors{msg: err.Error(), errs: errs}, SkipCaller)
+	case interface{ Unwrap() error }:
+		// fmt wraps a nil error when provided a nil %w operand, which is
+		// left out, the same as with an error that wraps nothing
+		if wrapped := t.Unwrap(); wrapped != nil {
+			return Wrap(&fmtError{msg: err.Error(), err: fromErrText(wrapped)}, SkipCaller)
+		}
+	}
+	return New(err.Error(), SkipCaller)
+}
+
+// isNilValue determines if the error is a typed nil, (i.e. a nil pointer).
+// fmt formats these as <nil> rather than calling their Error method, which
+// is left to fmt to do.
+func isNilValue(err error) bool {
+	v := reflect.ValueOf(err)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+func fromErrText(err error) error {
+	if t, ok := err.(errText); ok {
+		return t.err
+	}
+	return err
+}
+
+// errText formats an error with its Error text alone. fmt formats the
+// errors of this pkg with their stack frames otherwise.
+type errText struct {
+	err error
+}
+
+func (e errText) Error() string {
+	return e.err.Error()
+}
+
+// fmtError is the error of Errorf wrapping a single error.
+type fmtError struct {
+	msg string
+	err error
+}
+
+func (f *fmtError) Error() string {
+	return f.msg
+}
+
+func (f *fmtError) Unwrap() error {
+	return f.err
+}
+
+// fmtErrors is the error of Errorf wrapping multiple errors.
+type fmtErrors struct {
+	msg  string
+	errs []error
+}
+
+func (f *fmtErrors) Error() string {
+	return f.msg
+}
+
+func (f *fmtErrors) Unwrap() []error {
+	return f.errs
+}
+
+// WithStack captures the stack frame of the caller for the error. This is
+// the equivalent of calling Wrap without any options.
+func WithStack(err error) error {
+	return Wrap(err, SkipCaller)
+}
+
+// WithMessage wraps the error with the message. This is the equivalent of
+// calling Wrap with the message. Unlike github.com/pkg/errors, a stack frame
+// is captured as well.
+func WithMessage(err error, msg string) error {
+	return Wrap(err, msg, SkipCaller)
+}
+
+// WithMessagef wraps the error with the formatted message. Unlike github.com/pkg/errors,
+// a stack frame is captured as well.
+func WithMessagef(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return Wrap(err, fmt.Sprintf(format, args...), SkipCaller)
+}
+
+// Wrapf wraps the error with the formatted message.
+func Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return Wrap(err, fmt.Sprintf(format, args...), SkipCaller)
+}
@@ -0,0 +1,180 @@
+package errors_test
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+type causer struct{ cause error }
+
+func (c causer) Error() string { return "causer: " + c.cause.Error() }
+func (c causer) Cause() error  { return c.cause }
+
+func TestCause(t *testing.T) {
+	t.Run("nil error returns nil", func(t *testing.T) {
+		if err := errors.Cause(nil); err != nil {
+			t.Fatalf("unexpected cause:\n\t\tgot:\t%v", err)
+		}
+	})
+
+	t.Run("wrapped error returns innermost error", func(t *testing.T) {
+		base := fmt.Errorf("base")
+		err := errors.Wrap(errors.Wrap(fmt.Errorf("std wrap: %w", base), "inner"), "outer")
+
+		eq(t, base, errors.Cause(err))
+	})
+
+	t.Run("pkg/errors causer is honored", func(t *testing.T) {
+		base := fmt.Errorf("base")
+		err := errors.Wrap(causer{cause: base})
+
+		eq(t, base, errors.Cause(err))
+	})
+
+	t.Run("joined error is the cause", func(t *testing.T) {
+		joined := errors.Join(fmt.Errorf("first"), fmt.Errorf("second"))
+		err := errors.Wrap(joined)
+
+		eq(t, joined, errors.Cause(err))
+	})
+}
+
+func TestPkgErrorsCompat(t *testing.T) {
+	t.Run("Errorf", func(t *testing.T) {
+		err := errors.Errorf("simple %s %d", "msg", 1)
+
+		eq(t, "simple msg 1", err.Error())
+		eqStackLines(t, err, 48)
+	})
+
+	t.Run("Errorf with wrapped error", func(t *testing.T) {
+		err := errors.Errorf("wrapped: %w", sentinelErr)
+
+		eq(t, "wrapped: sentinel err", err.Error())
+		eq(t, true, errors.Is(err, sentinelErr))
+		eqStackLines(t, err, 55)
+	})
+
+	t.Run("WithStack", func(t *testing.T) {
+		eq(t, nil, errors.WithStack(nil))
+
+		err := errors.WithStack(sentinelErr)
+
+		eq(t, "sentinel err", err.Error())
+		eq(t, true, errors.Is(err, sentinelErr))
+		eqStackLines(t, err, 65)
+	})
+
+	t.Run("WithMessage", func(t *testing.T) {
+		eq(t, nil, errors.WithMessage(nil, "msg"))
+
+		err := errors.WithMessage(sentinelErr, "msg")
+
+		eq(t, "msg: sentinel err", err.Error())
+		eqStackLines(t, err, 75)
+	})
+
+	t.Run("WithMessagef", func(t *testing.T) {
+		eq(t, nil, errors.WithMessagef(nil, "msg %d", 1))
+
+		err := errors.WithMessagef(sentinelErr, "msg %d", 1)
+
+		eq(t, "msg 1: sentinel err", err.Error())
+		eqStackLines(t, err, 84)
+	})
+
+	t.Run("Wrapf", func(t *testing.T) {
+		eq(t, nil, errors.Wrapf(nil, "msg %d", 1))
+
+		err := errors.Wrapf(errors.New("inner"), "msg %d", 1)
+
+		eq(t, "msg 1: inner", err.Error())
+		eqStackLines(t, err, 93, 93)
+	})
+}
+
+func TestStackTracer(t *testing.T) {
+	err := errors.Wrap(
+		errors.New("inner"),
+	)
+
+	stackTracer, ok := err.(interface{ StackTrace() errors.StackFrames })
+	must(t, eq(t, true, ok))
+
+	frames := stackTracer.StackTrace()
+	must(t, eqLen(t, 2, frames))
+
+	// matches the order of github.com/pkg/errors, with the origin of the error first
+	eq(t, 102, frames[0].Line)
+	eq(t, 101, frames[1].Line)
+
+	for _, frame := range frames {
+		rf, _ := runtime.CallersFrames([]uintptr{frame.PC}).Next()
+		eq(t, frame.Line, rf.Line)
+		eq(t, frame.Fn, rf.Function)
+	}
+
+	// tooling like sentry probes for the method, and a PC field via reflection
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	must(t, eq(t, true, method.IsValid()))
+
+	reflected := method.Call(nil)[0]
+	must(t, eq(t, 2, reflected.Len()))
+	eq(t, reflect.Uintptr, reflected.Index(0).FieldByName("PC").Kind())
+}
+
+func eqStackLines(t *testing.T, err error, lines ...int) {
+	t.Helper()
+
+	frames := errors.StackTrace(err)
+	must(t, eqLen(t, len(lines), frames))
+	for i, line := range lines {
+		eq(t, "github.com/jsteenb2/errors/pkg_errors_test.go", frames[i].FilePath)
+		eq(t, line, frames[i].Line)
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	t.Run("with multiple wrapped errors", func(t *testing.T) {
+		other := errors.New("other err", errors.NoFrame)
+		err := errors.Errorf("wrapped: %w and %w", sentinelErr, other)
+
+		eq(t, "wrapped: sentinel err and other err", err.Error())
+		eq(t, true, errors.Is(err, sentinelErr))
+		eq(t, true, errors.Is(err, other))
+	})
+
+	t.Run("with an error of this pkg", func(t *testing.T) {
+		inner := errors.New("inner")
+		err := errors.Errorf("ctx: %w, %v", inner, errors.New("other"))
+
+		eq(t, "ctx: inner, other", err.Error())
+		eq(t, true, errors.Is(err, inner))
+	})
+
+	t.Run("with a nil wrapped error", func(t *testing.T) {
+		var nilErr error
+		err := errors.Errorf("ctx: %w", nilErr)
+
+		eq(t, "ctx: %!w(<nil>)", err.Error())
+		eq(t, nil, errors.Unwrap(err))
+	})
+
+	t.Run("with a typed nil error", func(t *testing.T) {
+		var nilErr *cyclicErr
+		err := errors.Errorf("ctx: %v", nilErr)
+
+		eq(t, "ctx: <nil>", err.Error())
+	})
+
+	t.Run("with a typed nil wrapped error", func(t *testing.T) {
+		var nilErr *cyclicErr
+		err := errors.Errorf("ctx: %w and %w", nilErr, sentinelErr)
+
+		eq(t, "ctx: <nil> and sentinel err", err.Error())
+	})
+}
@@ -0,0 +1,71 @@
+package errors
+
+// UserMessage is a message that is safe to show to the users of a service or
+// CLI. Unlike the message of an error, which is free to include the details
+// of the implementation, the UserMessage is returned by PublicMessage, and
+// is never included in the output of the Error method.
+//
+//	errors.Wrap(err, "failed to query users table", errors.UserMessage("unable to load your profile"))
+type UserMessage string
+
+// publicMessages are the generic messages of the canonical kinds.
+var publicMessages = map[Kind]string{
+	KindInvalidArgument:    "invalid argument",
+	KindNotFound:           "not found",
+	KindAlreadyExists:      "already exists",
+	KindPermissionDenied:   "permission denied",
+	KindUnauthenticated:    "unauthenticated",
+	KindResourceExhausted:  "resource exhausted",
+	KindFailedPrecondition: "failed precondition",
+	KindAborted:            "aborted",
+	KindUnavailable:        "service unavailable",
+	KindDeadlineExceeded:   "timed out",
+	KindCanceled:           "canceled",
+	KindInternal:           "internal error",
+	KindUnimplemented:      "not implemented",
+}
+
+// PublicMessage returns a message of the error that is safe to show to the
+// users of a service or CLI. The outermost UserMessage found in the error
+// tree is returned. When the error has no UserMessage, a generic message
+// of the Kind of the error is returned, (i.e. "not found" for KindNotFound),
+// and "internal error" when the Kind is unknown. The message of the error,
+// which often leaks the details of the implementation, (i.e. "pg: connection
+// refused"), is never returned. A nil error returns an empty message.
+//
+//	log.Error(err.Error(), errors.Fields(err)...)
+//	http.Error(w, errors.PublicMessage(err), errkind.HTTPStatus(err))
+func PublicMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var msg string
+	Walk(err, func(node error, _ int, _ []int) WalkAction {
+		msg = getErrMeta(node).userMsg
+		if msg != "" {
+			return WalkStop
+		}
+		return WalkContinue
+	})
+	if msg != "" {
+		return msg
+	}
+
+	for _, kind := range Kinds(err) {
+		if msg, ok := publicMessages[kind]; ok {
+			return msg
+		}
+	}
+	return KindMessage(KindOf(err))
+}
+
+// KindMessage returns the generic public message of the Kind, (i.e. "not found"
+// for KindNotFound), and "internal error" when the Kind is unknown. See
+// PublicMessage.
+func KindMessage(kind Kind) string {
+	if msg, ok := publicMessages[kind]; ok {
+		return msg
+	}
+	return publicMessages[KindInternal]
+}
@@ -0,0 +1,84 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestPublicMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		input error
+		want  string
+	}{
+		{
+			name:  "nil error",
+			input: nil,
+			want:  "",
+		},
+		{
+			name:  "user message",
+			input: errors.New("pg: connection refused", errors.UserMessage("unable to load your profile")),
+			want:  "unable to load your profile",
+		},
+		{
+			name: "outermost user message",
+			input: errors.Wrap(
+				errors.New("pg: connection refused", errors.UserMessage("database is unavailable")),
+				"failed to query users table",
+				errors.UserMessage("unable to load your profile"),
+			),
+			want: "unable to load your profile",
+		},
+		{
+			name:  "user message of a wrapped error",
+			input: fmt.Errorf("failed to query users table: %w", errors.New("pg: connection refused", errors.UserMessage("database is unavailable"))),
+			want:  "database is unavailable",
+		},
+		{
+			name:  "user message of a join",
+			input: errors.Join(errors.New("first"), errors.New("second"), errors.UserMessage("batch failed")),
+			want:  "batch failed",
+		},
+		{
+			name:  "without user message falls back to the kind",
+			input: errors.Wrap(errors.New("user 1 not found", errors.KindNotFound), "failed to query users table"),
+			want:  "not found",
+		},
+		{
+			name:  "without user message falls back to the kind of a wrapped error",
+			input: fmt.Errorf("pg: %w", errors.New("deadline", errors.KindDeadlineExceeded)),
+			want:  "timed out",
+		},
+		{
+			name:  "without user message or canonical kind",
+			input: errors.New("pg: connection refused", errors.Kind("db")),
+			want:  "internal error",
+		},
+		{
+			name:  "foreign error",
+			input: fmt.Errorf("pg: connection refused"),
+			want:  "internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eq(t, tt.want, errors.PublicMessage(tt.input))
+		})
+	}
+
+	t.Run("user message is not part of the Error output", func(t *testing.T) {
+		err := errors.New("pg: connection refused", errors.NoFrame, errors.UserMessage("unable to load your profile"))
+		eq(t, "pg: connection refused", err.Error())
+	})
+}
+
+func TestKindMessage(t *testing.T) {
+	eq(t, "not found", errors.KindMessage(errors.KindNotFound))
+	eq(t, "timed out", errors.KindMessage(errors.KindDeadlineExceeded))
+	eq(t, "internal error", errors.KindMessage(errors.Kind("db")))
+	eq(t, "internal error", errors.KindMessage(""))
+}
@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RenderTree writes an indented tree of the error to w. Each error of
+// the tree is written on its own line, with the message, Kind, KVs and
+// Frame it contributes. Joined errors branch off of the join:
+//
+//	outer kind=invalid user=1 @ github.com/org/pkg/foo.go:12[Foo]
+//	└── 2 errors joined @ github.com/org/pkg/foo.go:20[foo]
+//	    ├── first @ github.com/org/pkg/foo.go:21[foo]
+//	    └── second
+func RenderTree(w io.Writer, err error) error {
+	layers := Inspect(err)
+	if len(layers) == 0 {
+		return nil
+	}
+
+	bw := bufio.NewWriter(w)
+	renderTreeNode(bw, layers, "", "", "")
+	return bw.Flush()
+}
+
+func renderTreeNode(w *bufio.Writer, layers []Layer, prefix, connector, childPrefix string) {
+	layer := layers[0]
+	w.WriteString(prefix + connector + strings.Join(layerLabel(layer), " ") + "\n")
+
+	kids := layerKids(layers)
+	for i, kid := range kids {
+		if i == len(kids)-1 {
+			renderTreeNode(w, kid, childPrefix, "└── ", childPrefix+"    ")
+			continue
+		}
+		renderTreeNode(w, kid, childPrefix, "├── ", childPrefix+"│   ")
+	}
+}
+
+// RenderDOT writes the error tree to w in the Graphviz DOT language. This
+// is useful for visualizing deeply nested joined errors:
+//
+//	errors.RenderDOT(f, err)
+//	// dot -Tsvg err.dot > err.svg
+func RenderDOT(w io.Writer, err error) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph errors {\n\tnode [shape=box];\n")
+	if layers := Inspect(err); len(layers) > 0 {
+		var id int
+		renderDOTNode(bw, layers, &id)
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+func renderDOTNode(w *bufio.Writer, layers []Layer, nextID *int) string {
+	id := "n" + strconv.Itoa(*nextID)
+	*nextID++
+
+	label := strings.Join(layerLabel(layers[0]), "\n")
+	fmt.Fprintf(w, "\t%s [label=%s];\n", id, strconv.Quote(label))
+	for _, kid := range layerKids(layers) {
+		kidID := renderDOTNode(w, kid, nextID)
+		fmt.Fprintf(w, "\t%s -> %s;\n", id, kidID)
+	}
+	return id
+}
+
+// layerKids returns the layers following the first layer. The next layer of
+// the chain is the only child of a wrapping error, while a join has the
+// layers of each joined error as its children. A joined error that is one
+// of its own ancestors has no layers, and is left out.
+func layerKids(layers []Layer) [][]Layer {
+	if len(layers) > 1 {
+		return [][]Layer{layers[1:]}
+	}
+
+	var kids [][]Layer
+	for _, child := range layers[0].Children {
+		if len(child) > 0 {
+			kids = append(kids, child)
+		}
+	}
+	return kids
+}
+
+func layerLabel(layer Layer) []string {
+	msg := layer.Msg
+	if msg == "" {
+		msg = layer.Type
+		if len(layer.Children) > 0 {
+			msg = fmt.Sprintf("%d errors joined", len(layer.Children))
+		}
+	}
+
+	out := []string{msg}
+	if layer.Kind != "" {
+		out = append(out, "kind="+string(layer.Kind))
+	}
+	for _, kv := range slices.Concat(layer.KVs, layer.StdKVs) {
+		out = append(out, fmt.Sprintf("%s=%v", kv.K, kv.V))
+	}
+	for _, h := range layer.Hints {
+		if h.Hint != "" {
+			out = append(out, "hint="+strconv.Quote(h.Hint))
+		}
+		if h.HelpURL != "" {
+			out = append(out, "help="+h.HelpURL)
+		}
+	}
+	if layer.Frame.FilePath != "" {
+		out = append(out, "@ "+layer.Frame.String())
+	}
+	return out
+}
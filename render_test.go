@@ -0,0 +1,84 @@
+package errors_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestRenderTree(t *testing.T) {
+	t.Run("with nil error should write nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.RenderTree(&buf, nil)))
+		eq(t, "", buf.String())
+	})
+
+	t.Run("with joins of joins", func(t *testing.T) {
+		err := errors.Wrap(
+			errors.Join(
+				errors.Wrap(errors.New("first", errors.NoFrame), "wrapped", errors.NoFrame, errors.KVs("k", "v")),
+				errors.Join(
+					fmt.Errorf("std: %w", sentinelErr),
+					errors.New("nested", errors.Kind("nested_kind"), errors.NoFrame),
+					errors.NoFrame,
+				),
+				errors.NoFrame,
+			),
+			"outer",
+			errors.Kind("invalid"),
+		)
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.RenderTree(&buf, err)))
+
+		want := `outer kind=invalid @ github.com/jsteenb2/errors/render_test.go:20[TestRenderTree.func2]
+└── 2 errors joined
+    ├── wrapped k=v
+    │   └── first
+    └── 2 errors joined
+        ├── std
+        │   └── sentinel err
+        └── nested kind=nested_kind
+`
+		eq(t, want, buf.String())
+	})
+	t.Run("with cyclic error tree", func(t *testing.T) {
+		cyclic := &cyclicErr{msg: "cycle"}
+		cyclic.next = errors.Join(cyclic, errors.New("other", errors.NoFrame), errors.NoFrame)
+
+		var buf bytes.Buffer
+		must(t, eq(t, nil, errors.RenderTree(&buf, cyclic)))
+		eq(t, "cycle\n└── 2 errors joined\n    └── other\n", buf.String())
+
+		buf.Reset()
+		must(t, eq(t, nil, errors.RenderDOT(&buf, cyclic)))
+		eq(t, 2, strings.Count(buf.String(), "->"))
+	})
+}
+
+func TestRenderDOT(t *testing.T) {
+	err := errors.Join(
+		errors.New("first", errors.NoFrame, errors.KVs("quoted", `"v"`)),
+		errors.Wrap(sentinelErr, "second", errors.NoFrame),
+		errors.NoFrame,
+	)
+
+	var buf bytes.Buffer
+	must(t, eq(t, nil, errors.RenderDOT(&buf, err)))
+
+	want := `digraph errors {
+	node [shape=box];
+	n0 [label="2 errors joined"];
+	n1 [label="first\nquoted=\"v\""];
+	n0 -> n1;
+	n2 [label="second"];
+	n3 [label="sentinel err"];
+	n2 -> n3;
+	n0 -> n2;
+}
+`
+	eq(t, want, buf.String())
+}
@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// Rule classifies the errors of other libraries, (i.e. database drivers,
+// cloud SDKs or parsers), normalizing them into the Kinds, codes and KVs
+// of your own. Rules are registered with RegisterRules, and are applied
+// when a foreign error is wrapped with Wrap.
+type Rule struct {
+	// Match determines if the rule applies to the error. See MatchType,
+	// MatchIs and MatchMessage for the common matchers.
+	Match func(err error) bool
+
+	// Kind is provided to the wrapping error, unless a Kind is provided
+	// to Wrap or is found in the wrapped error.
+	Kind Kind
+
+	// Code is provided to the wrapping error as the err_code KV.
+	Code string
+
+	// KVs are provided to the wrapping error.
+	KVs []KV
+}
+
+// MatchType matches errors that have an error of type T in their error
+// tree, the same as errors.As.
+//
+//	errors.Rule{Match: errors.MatchType[*pq.Error](), Kind: KindDB}
+func MatchType[T error]() func(error) bool {
+	return func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}
+}
+
+// MatchIs matches errors that have the target in their error tree, the
+// same as errors.Is.
+//
+//	errors.Rule{Match: errors.MatchIs(sql.ErrNoRows), Kind: errors.KindNotFound}
+func MatchIs(target error) func(error) bool {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// MatchMessage matches errors with a message matching the regular expression.
+// MatchMessage panics when the expression does not compile.
+//
+//	errors.Rule{Match: errors.MatchMessage(`(?i)deadlock detected`), Kind: KindConflict}
+func MatchMessage(expr string) func(error) bool {
+	re := regexp.MustCompile(expr)
+	return func(err error) bool {
+		return re.MatchString(err.Error())
+	}
+}
+
+var ruleRegistry struct {
+	mu    sync.RWMutex
+	rules []registeredRule
+	batch int
+}
+
+type registeredRule struct {
+	Rule
+	batch int
+}
+
+// RegisterRules registers the rules for classifying foreign errors. The rules
+// are applied, in the order they are registered, when a foreign error is wrapped
+// with Wrap. The first matching rule is applied. Rules without a Match are
+// ignored. The returned func unregisters the rules, which is useful in tests.
+//
+//	func init() {
+//		errors.RegisterRules(
+//			errors.Rule{Match: errors.MatchIs(sql.ErrNoRows), Kind: errors.KindNotFound, Code: "DB_NO_ROWS"},
+//			errors.Rule{Match: errors.MatchType[*pq.Error](), Kind: KindDB, KVs: errors.KVs("db", "postgres")},
+//		)
+//	}
+func RegisterRules(rules ...Rule) (unregister func()) {
+	ruleRegistry.mu.Lock()
+	defer ruleRegistry.mu.Unlock()
+
+	ruleRegistry.batch++
+	batch := ruleRegistry.batch
+	for _, r := range rules {
+		if r.Match != nil {
+			ruleRegistry.rules = append(ruleRegistry.rules, registeredRule{Rule: r, batch: batch})
+		}
+	}
+
+	return func() {
+		ruleRegistry.mu.Lock()
+		defer ruleRegistry.mu.Unlock()
+		// the rules are cloned, as the matchers may be running against
+		// the current rules, see matchRule
+		ruleRegistry.rules = slices.DeleteFunc(slices.Clone(ruleRegistry.rules), func(r registeredRule) bool {
+			return r.batch == batch
+		})
+	}
+}
+
+// matchRule returns the first registered rule matching the error.
+func matchRule(err error) (Rule, bool) {
+	if err == nil {
+		return Rule{}, false
+	}
+
+	// the matchers are run without holding the lock, as a matcher may
+	// create errors of its own, which would deadlock with a pending
+	// RegisterRules. The registered rules are only ever appended to or
+	// replaced, so the slice is safe to range over once released.
+	ruleRegistry.mu.RLock()
+	rules := ruleRegistry.rules
+	ruleRegistry.mu.RUnlock()
+
+	for _, r := range rules {
+		if r.Match(err) {
+			return r.Rule, true
+		}
+	}
+	return Rule{}, false
+}
@@ -0,0 +1,128 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jsteenb2/errors"
+)
+
+type driverErr struct{ code string }
+
+func (d *driverErr) Error() string { return "driver error " + d.code }
+
+func TestRegisterRules(t *testing.T) {
+	unregister := errors.RegisterRules(
+		errors.Rule{
+			Match: errors.MatchType[*driverErr](),
+			Kind:  "db",
+			Code:  "DB_ERR",
+			KVs:   errors.KVs("db", "postgres"),
+		},
+		errors.Rule{
+			Match: errors.MatchIs(io.ErrUnexpectedEOF),
+			Kind:  "truncated",
+		},
+		errors.Rule{
+			Match: errors.MatchMessage(`(?i)deadlock detected`),
+			Kind:  "conflict",
+			Code:  "DEADLOCK",
+		},
+		errors.Rule{Kind: "ignored without a match"},
+	)
+	t.Cleanup(unregister)
+
+	t.Run("match by type", func(t *testing.T) {
+		err := errors.Wrap(fmt.Errorf("query: %w", &driverErr{code: "42P01"}), errors.KVs("table", "users"))
+
+		eq(t, true, errors.Is(err, errors.Kind("db")))
+		eqV(t, err, "err_code", "DB_ERR")
+		eqV(t, err, "db", "postgres")
+		eqV(t, err, "table", "users")
+	})
+
+	t.Run("match by sentinel", func(t *testing.T) {
+		err := errors.Wrap(io.ErrUnexpectedEOF)
+
+		eq(t, true, errors.Is(err, errors.Kind("truncated")))
+		eq(t, nil, errors.V(err, "err_code"))
+	})
+
+	t.Run("match by message", func(t *testing.T) {
+		err := errors.Wrap(fmt.Errorf("ERROR: Deadlock detected"))
+
+		eq(t, errors.Kind("conflict"), errors.KindOf(err))
+		eqV(t, err, "err_code", "DEADLOCK")
+	})
+
+	t.Run("KindOf consults the rules for unwrapped errors", func(t *testing.T) {
+		eq(t, errors.Kind("truncated"), errors.KindOf(io.ErrUnexpectedEOF))
+	})
+
+	t.Run("provided kind takes precedence", func(t *testing.T) {
+		err := errors.Wrap(io.ErrUnexpectedEOF, errors.Kind("provided"))
+
+		eq(t, errors.Kind("provided"), errors.KindOf(err))
+		eq(t, false, errors.Is(err, errors.Kind("truncated")))
+	})
+
+	t.Run("rules are only applied to foreign errors", func(t *testing.T) {
+		inner := errors.Wrap(&driverErr{code: "1"})
+		err := errors.Wrap(inner)
+
+		var codes int
+		for _, kv := range errors.Fields(err) {
+			if kv == "err_code" {
+				codes++
+			}
+		}
+		eq(t, 1, codes)
+	})
+
+	t.Run("unmatched errors are left as is", func(t *testing.T) {
+		_, parseErr := strconv.Atoi("nan")
+		err := errors.Wrap(parseErr)
+
+		eq(t, errors.Kind(""), errors.KindOf(err))
+		eq(t, nil, errors.V(err, "err_code"))
+	})
+
+	t.Run("unregistered rules are no longer applied", func(t *testing.T) {
+		unregisterOther := errors.RegisterRules(errors.Rule{Match: errors.MatchIs(io.ErrClosedPipe), Kind: "closed"})
+		eq(t, errors.Kind("closed"), errors.KindOf(errors.Wrap(io.ErrClosedPipe)))
+
+		unregisterOther()
+		eq(t, errors.Kind(""), errors.KindOf(errors.Wrap(io.ErrClosedPipe)))
+		eq(t, errors.Kind("truncated"), errors.KindOf(errors.Wrap(io.ErrUnexpectedEOF)))
+	})
+
+	t.Run("matchers may create errors while rules are registered", func(t *testing.T) {
+		target := fmt.Errorf("reentrant")
+		unregisterOther := errors.RegisterRules(errors.Rule{
+			Match: func(err error) bool {
+				if err != target {
+					return false
+				}
+
+				registered := make(chan struct{})
+				go func() {
+					defer close(registered)
+					errors.RegisterRules(errors.Rule{Match: errors.MatchIs(io.EOF)})()
+				}()
+				select {
+				case <-registered:
+				case <-time.After(time.Second):
+					t.Error("RegisterRules blocked by a running matcher")
+				}
+				return errors.Wrap(fmt.Errorf("from matcher")) != nil
+			},
+			Kind: "reentrant",
+		})
+		defer unregisterOther()
+
+		eq(t, errors.Kind("reentrant"), errors.KindOf(errors.Wrap(target)))
+	})
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// stdErrKVs returns the structured details of the std lib error types, that
+// are otherwise only available in the error's message. The keys are the
+// snake cased field names of the error type.
+func stdErrKVs(err error) []KV {
+	switch err := err.(type) {
+	case *os.PathError:
+		return []KV{{K: "op", V: err.Op}, {K: "path", V: err.Path}}
+	case *os.LinkError:
+		return []KV{{K: "op", V: err.Op}, {K: "old", V: err.Old}, {K: "new", V: err.New}}
+	case *os.SyscallError:
+		return []KV{{K: "syscall", V: err.Syscall}}
+	case *net.OpError:
+		kvs := []KV{{K: "op", V: err.Op}, {K: "net", V: err.Net}}
+		if err.Source != nil {
+			kvs = append(kvs, KV{K: "source", V: err.Source.String()})
+		}
+		if err.Addr != nil {
+			kvs = append(kvs, KV{K: "addr", V: err.Addr.String()})
+		}
+		return kvs
+	case *net.DNSError:
+		kvs := []KV{{K: "name", V: err.Name}}
+		if err.Server != "" {
+			kvs = append(kvs, KV{K: "server", V: err.Server})
+		}
+		return append(kvs, KV{K: "is_timeout", V: err.IsTimeout}, KV{K: "is_not_found", V: err.IsNotFound})
+	case *url.Error:
+		return []KV{{K: "op", V: err.Op}, {K: "url", V: err.URL}}
+	case *exec.ExitError:
+		return []KV{{K: "exit_code", V: err.ExitCode()}}
+	case *strconv.NumError:
+		return []KV{{K: "func", V: err.Func}, {K: "num", V: err.Num}}
+	case *json.SyntaxError:
+		return []KV{{K: "offset", V: err.Offset}}
+	}
+	return nil
+}
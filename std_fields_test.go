@@ -0,0 +1,118 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+func TestFields_StdLibErrors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		name       string
+		input      func(t *testing.T) error
+		wantFields []any
+	}{
+		{
+			name: "os.PathError",
+			input: func(t *testing.T) error {
+				_, err := os.Open(missing)
+				return err
+			},
+			wantFields: []any{"op", "open", "path", missing},
+		},
+		{
+			name: "os.LinkError",
+			input: func(t *testing.T) error {
+				return os.Link(missing, missing+"_new")
+			},
+			wantFields: []any{"op", "link", "old", missing, "new", missing + "_new"},
+		},
+		{
+			name: "net.OpError",
+			input: func(t *testing.T) error {
+				return &net.OpError{
+					Op:   "dial",
+					Net:  "tcp",
+					Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080},
+					Err:  os.ErrDeadlineExceeded,
+				}
+			},
+			wantFields: []any{"op", "dial", "net", "tcp", "addr", "127.0.0.1:8080"},
+		},
+		{
+			name: "net.DNSError",
+			input: func(t *testing.T) error {
+				return &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}
+			},
+			wantFields: []any{"name", "example.invalid", "is_timeout", false, "is_not_found", true},
+		},
+		{
+			name: "url.Error",
+			input: func(t *testing.T) error {
+				return &url.Error{Op: "Get", URL: "http://example.com", Err: os.ErrDeadlineExceeded}
+			},
+			wantFields: []any{"op", "Get", "url", "http://example.com"},
+		},
+		{
+			name: "exec.ExitError",
+			input: func(t *testing.T) error {
+				sh, err := exec.LookPath("sh")
+				if err != nil {
+					t.Skip("sh is not available")
+				}
+				return exec.Command(sh, "-c", "exit 3").Run()
+			},
+			wantFields: []any{"exit_code", 3},
+		},
+		{
+			name: "strconv.NumError",
+			input: func(t *testing.T) error {
+				_, err := strconv.Atoi("nan")
+				return err
+			},
+			wantFields: []any{"func", "Atoi", "num", "nan"},
+		},
+		{
+			name: "json.SyntaxError",
+			input: func(t *testing.T) error {
+				return json.Unmarshal([]byte(`{"bad"`), new(any))
+			},
+			wantFields: []any{"offset", int64(6)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.Wrap(tt.input(t), errors.NoFrame)
+
+			eqFields(t, tt.wantFields, errors.Fields(err))
+		})
+	}
+
+	t.Run("fields are accessible with V", func(t *testing.T) {
+		_, err := strconv.ParseInt("nan", 10, 64)
+
+		eqV(t, err, "num", "nan")
+		eqV(t, errors.Wrap(err), "func", "ParseInt")
+	})
+
+	t.Run("fields are kept apart from the KVs of the layers", func(t *testing.T) {
+		err := errors.Wrap(&os.PathError{Op: "open", Path: "/tmp/foo", Err: os.ErrNotExist}, errors.KVs("k", "v"))
+
+		layers := errors.Inspect(err)
+		must(t, eqLen(t, 3, layers))
+		eq(t, 1, len(layers[0].KVs))
+		eq(t, 0, len(layers[1].KVs))
+		must(t, eqLen(t, 2, layers[1].StdKVs))
+		eq(t, errors.KV{K: "path", V: "/tmp/foo"}, layers[1].StdKVs[1])
+	})
+}
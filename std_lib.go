@@ -11,17 +11,28 @@ func As(err error, target any) bool {
 	return errors.As(err, target)
 }
 
-// Is is a direct callout to the std lib errors.Is function. This
-// allows users to only ever have to worry about including one
-// errors pkg.
+// Is is a callout to the std lib errors.Is function. This allows
+// users to only ever have to worry about including one errors pkg.
+// When the target is a Kind, the error tree is searched for the Kind,
+// including errors implementing Kinder, which the std lib errors.Is
+// has no way of matching on its own.
 func Is(err, target error) bool {
+	if kind, ok := target.(Kind); ok && hasKind(err, kind) {
+		return true
+	}
 	return errors.Is(err, target)
 }
 
-// Unwrap is a direct callout to the std lib errors.Unwrap function.
-// This allows users to only ever have to worry about including one
-// errors pkg. The use of the std lib errors.Unwrap is a now thing,
-// but may change in future releases.
+// Unwrap is a callout to the std lib errors.Unwrap function. This
+// allows users to only ever have to worry about including one errors
+// pkg. The one exception is for errors created by Join. Where the
+// std lib errors.Unwrap returns nil for a joined error, this returns
+// the first joined error, which can be unwrapped further to walk the
+// remaining joined errors in order. Use Disjoin to obtain the joined
+// errors directly.
 func Unwrap(err error) error {
+	if ej, ok := err.(*joinE); ok {
+		return ej.unwrapChain()
+	}
 	return errors.Unwrap(err)
 }
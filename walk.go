@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"reflect"
+)
+
+// WalkAction instructs Walk on how to proceed after visiting an error.
+type WalkAction int
+
+const (
+	// WalkContinue continues the walk, visiting the errors wrapped by
+	// the current error.
+	WalkContinue WalkAction = iota
+
+	// WalkSkip skips the errors wrapped by the current error. The walk
+	// continues with the current error's siblings, if any.
+	WalkSkip
+
+	// WalkStop stops the walk entirely.
+	WalkStop
+)
+
+// WalkFn is called for every error visited by Walk. The depth is the
+// number of unwraps taken to reach the node from the root error. The
+// path is the index of the error amongst its siblings at each depth,
+// single wrapped errors always have an index of 0. For example, the
+// path [0 2] is the third joined error, of the error wrapped by the root.
+type WalkFn func(node error, depth int, path []int) WalkAction
+
+// Walk traverses the error tree of err depth first, calling fn for each
+// error in the tree, starting with err itself. Errors wrapping a single
+// error, (i.e. Wrap or fmt.Errorf with %w), and errors wrapping multiple
+// errors, (i.e. Join or the std lib errors.Join), are treated uniformly.
+// The joined errors are visited in the order they were joined.
+//
+// An error that wraps one of its own ancestors is not visited again,
+// protecting against cycles in the tree.
+func Walk(err error, fn WalkFn) {
+	walk(err, nil, nil, fn)
+}
+
+func walk(err error, path []int, ancestors []error, fn WalkFn) (stop bool) {
+	if err == nil || isAncestor(err, ancestors) {
+		return false
+	}
+
+	switch fn(err, len(path), path) {
+	case WalkStop:
+		return true
+	case WalkSkip:
+		return false
+	}
+
+	ancestors = append(ancestors, err)
+	for i, child := range children(err) {
+		// the full slice expression forces a copy, so the path
+		// provided to fn is safe to retain
+		if walk(child, append(path[:len(path):len(path)], i), ancestors, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// children returns the errors directly wrapped by err.
+func children(err error) []error {
+	switch err := err.(type) {
+	case chain:
+		return err
+	case interface{ Unwrap() []error }:
+		return err.Unwrap()
+	case interface{ Unwrap() error }:
+		if next := err.Unwrap(); next != nil {
+			return []error{next}
+		}
+	}
+	return nil
+}
+
+func isAncestor(err error, ancestors []error) bool {
+	if !reflect.TypeOf(err).Comparable() {
+		return false
+	}
+	for _, a := range ancestors {
+		if a == err {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jsteenb2/errors"
+)
+
+type cyclicErr struct {
+	msg  string
+	next error
+}
+
+func (c *cyclicErr) Error() string { return c.msg }
+func (c *cyclicErr) Unwrap() error { return c.next }
+
+func TestWalk(t *testing.T) {
+	newTree := func() error {
+		return errors.Wrap(
+			errors.Join(
+				errors.New("first", errors.NoFrame),
+				stderrors.Join(
+					fmt.Errorf("std: %w", sentinelErr),
+				),
+				errors.New("third", errors.NoFrame),
+				errors.NoFrame,
+			),
+			"root",
+			errors.NoFrame,
+		)
+	}
+
+	type visit struct {
+		msg   string
+		depth int
+		path  string
+	}
+
+	walkAll := func(err error, actionFn func(node error) errors.WalkAction) []visit {
+		var visits []visit
+		errors.Walk(err, func(node error, depth int, path []int) errors.WalkAction {
+			visits = append(visits, visit{
+				msg:   strings.SplitN(node.Error(), "\n", 2)[0],
+				depth: depth,
+				path:  fmt.Sprint(path),
+			})
+			return actionFn(node)
+		})
+		return visits
+	}
+
+	eqVisits := func(t *testing.T, want, got []visit) {
+		t.Helper()
+
+		must(t, eqLen(t, len(want), got))
+		for i := range want {
+			eq(t, want[i], got[i])
+		}
+	}
+
+	t.Run("visits every error depth first", func(t *testing.T) {
+		got := walkAll(newTree(), func(error) errors.WalkAction { return errors.WalkContinue })
+
+		want := []visit{
+			{msg: "root: 3 errors occurred:", depth: 0, path: "[]"},
+			{msg: "3 errors occurred:", depth: 1, path: "[0]"},
+			{msg: "first", depth: 2, path: "[0 0]"},
+			{msg: "std: sentinel err", depth: 2, path: "[0 1]"},
+			{msg: "std: sentinel err", depth: 3, path: "[0 1 0]"},
+			{msg: "sentinel err", depth: 4, path: "[0 1 0 0]"},
+			{msg: "third", depth: 2, path: "[0 2]"},
+		}
+		eqVisits(t, want, got)
+	})
+
+	t.Run("skip does not visit wrapped errors", func(t *testing.T) {
+		got := walkAll(newTree(), func(node error) errors.WalkAction {
+			if _, ok := node.(interface{ Unwrap() []error }); ok && node.Error() == "std: sentinel err" {
+				return errors.WalkSkip
+			}
+			return errors.WalkContinue
+		})
+
+		want := []visit{
+			{msg: "root: 3 errors occurred:", depth: 0, path: "[]"},
+			{msg: "3 errors occurred:", depth: 1, path: "[0]"},
+			{msg: "first", depth: 2, path: "[0 0]"},
+			{msg: "std: sentinel err", depth: 2, path: "[0 1]"},
+			{msg: "third", depth: 2, path: "[0 2]"},
+		}
+		eqVisits(t, want, got)
+	})
+
+	t.Run("stop ends the walk", func(t *testing.T) {
+		got := walkAll(newTree(), func(node error) errors.WalkAction {
+			if node == sentinelErr {
+				return errors.WalkStop
+			}
+			return errors.WalkContinue
+		})
+
+		must(t, eqLen(t, 6, got))
+		eq(t, "sentinel err", got[5].msg)
+	})
+
+	t.Run("chain from Unwrap is walked as siblings", func(t *testing.T) {
+		joined := errors.Join(fmt.Errorf("first"), fmt.Errorf("second"))
+
+		got := walkAll(errors.Unwrap(joined), func(error) errors.WalkAction { return errors.WalkContinue })
+
+		want := []visit{
+			{msg: "first", depth: 0, path: "[]"},
+			{msg: "first", depth: 1, path: "[0]"},
+			{msg: "second", depth: 1, path: "[1]"},
+		}
+		eqVisits(t, want, got)
+	})
+
+	t.Run("cycles are visited once", func(t *testing.T) {
+		first := &cyclicErr{msg: "first"}
+		second := &cyclicErr{msg: "second", next: first}
+		first.next = second
+
+		got := walkAll(first, func(error) errors.WalkAction { return errors.WalkContinue })
+
+		want := []visit{
+			{msg: "first", depth: 0, path: "[]"},
+			{msg: "second", depth: 1, path: "[0]"},
+		}
+		eqVisits(t, want, got)
+	})
+
+	t.Run("nil error is not visited", func(t *testing.T) {
+		got := walkAll(nil, func(error) errors.WalkAction { return errors.WalkContinue })
+		eqLen(t, 0, got)
+	})
+}